@@ -11,6 +11,7 @@ import (
 	_ "net/http/pprof"
 
 	"github.com/thinkgos/timer"
+	"github.com/thinkgos/timer/debughttp"
 )
 
 // almost 1,000,000 task
@@ -48,6 +49,7 @@ func main() {
 		}
 	}()
 
+	http.Handle("/debug/timer/", http.StripPrefix("/debug/timer", debughttp.New(timer.DefaultTimer())))
 	addr := ":9990"
 	log.Printf("http stated '%v'\n", addr)
 	log.Println(http.ListenAndServe(addr, nil))
@@ -0,0 +1,69 @@
+package timer
+
+import "time"
+
+// ChanOption customize the channel delivery of After and AfterChan.
+type ChanOption func(*chanDelivery)
+
+type chanDelivery struct {
+	block bool // blocking send or not.
+}
+
+// WithDropOnFull deliver by a non-blocking send, the value is dropped if the channel is full.
+// The send is run inline in the timer's goroutine, no goroutine hand-off per firing.
+// It is the default.
+func WithDropOnFull() ChanOption {
+	return func(cd *chanDelivery) { cd.block = false }
+}
+
+// WithBlockingSend deliver by a blocking send in the `GoPool`, the value is never dropped.
+func WithBlockingSend() ChanOption {
+	return func(cd *chanDelivery) { cd.block = true }
+}
+
+// After waits for the duration to elapse and then sends the current time on the returned channel.
+// The returned task can be used to cancel the delivery.
+// NOTE: if the timer is closed, the channel never receives and the task is not activated.
+func (t *Timer) After(d time.Duration) (<-chan time.Time, *Task) {
+	ch := make(chan time.Time, 1)
+	// the channel has enough space, the non-blocking send never drops.
+	task := newChanTask(d, func() {
+		select {
+		case ch <- time.Now():
+		default:
+		}
+	}, true)
+	_ = t.AddTask(task)
+	return ch, task
+}
+
+// AfterChan waits for the duration to elapse and then sends v on ch.
+// default deliver by a non-blocking send, see WithDropOnFull and WithBlockingSend.
+func AfterChan[T any](t *Timer, d time.Duration, ch chan<- T, v T, opts ...ChanOption) (*Task, error) {
+	cd := chanDelivery{}
+	for _, opt := range opts {
+		opt(&cd)
+	}
+	var send func()
+	if cd.block {
+		send = func() { ch <- v }
+	} else {
+		send = func() {
+			select {
+			case ch <- v:
+			default:
+			}
+		}
+	}
+	task := newChanTask(d, send, !cd.block)
+	if err := t.AddTask(task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func newChanTask(d time.Duration, send func(), inline bool) *Task {
+	task := NewTaskFunc(d, send).WithNoSpreading()
+	task.inline = inline
+	return task
+}
@@ -0,0 +1,86 @@
+package timer
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testCountPool struct {
+	count atomic.Int64
+}
+
+func (p *testCountPool) Go(f func()) {
+	p.count.Add(1)
+	go f()
+}
+
+func Test_Timer_After(t *testing.T) {
+	pool := &testCountPool{}
+	tm := NewTimer(WithGoPool(pool))
+
+	// timer closed
+	ch, task := tm.After(time.Millisecond)
+	require.NotNil(t, ch)
+	require.False(t, task.Activated())
+
+	tm.Start()
+	defer tm.Stop()
+
+	start := time.Now()
+	ch, task = tm.After(20 * time.Millisecond)
+	require.True(t, task.Activated())
+	v := <-ch
+	require.GreaterOrEqual(t, v.Sub(start), 19*time.Millisecond)
+
+	// cancel
+	ch, task = tm.After(20 * time.Millisecond)
+	task.Cancel()
+	select {
+	case <-ch:
+		t.Fatal("canceled task should not deliver")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// already expired
+	ch, _ = tm.After(0)
+	<-ch
+
+	// no goroutine hand-off
+	require.Zero(t, pool.count.Load())
+}
+
+func Test_AfterChan(t *testing.T) {
+	pool := &testCountPool{}
+	tm := NewTimer(WithGoPool(pool))
+
+	_, err := AfterChan(tm, time.Millisecond, make(chan int), 1)
+	require.ErrorIs(t, err, ErrClosed)
+
+	tm.Start()
+	defer tm.Stop()
+
+	t.Run("drop on full", func(t *testing.T) {
+		ch := make(chan int, 1)
+		for i := 1; i <= 3; i++ {
+			_, err := AfterChan(tm, 10*time.Millisecond, ch, i, WithDropOnFull())
+			require.NoError(t, err)
+		}
+		time.Sleep(50 * time.Millisecond)
+		require.Len(t, ch, 1)
+		<-ch
+		require.Zero(t, pool.count.Load())
+	})
+
+	t.Run("blocking send", func(t *testing.T) {
+		ch := make(chan string)
+		task, err := AfterChan(tm, 10*time.Millisecond, ch, "hello", WithBlockingSend())
+		require.NoError(t, err)
+		require.True(t, task.Activated())
+		time.Sleep(50 * time.Millisecond)
+		require.Equal(t, "hello", <-ch)
+		require.Equal(t, int64(1), pool.count.Load())
+	})
+}
@@ -0,0 +1,170 @@
+package timer
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// clockJumpThresholdMs the minimum change of the offset between the wall clock
+// and the monotonic clock, which is treated as a wall clock jump, unit is milliseconds.
+const clockJumpThresholdMs = 1000
+
+// ClockJumpPolicy the policy of the absolute-time tasks when the wall clock jumps.
+// The relative-delay tasks are based on the monotonic clock, they are never affected.
+type ClockJumpPolicy int
+
+const (
+	ClockJumpPolicy_Reevaluate      ClockJumpPolicy = iota // re-evaluate the expiration against the new wall clock.
+	ClockJumpPolicy_FireImmediately                        // fire the pending absolute-time tasks immediately.
+	ClockJumpPolicy_Ignore                                 // keep the expiration, as if the wall clock not jumped.
+)
+
+// String implements fmt.Stringer.
+func (p ClockJumpPolicy) String() string {
+	switch p {
+	case ClockJumpPolicy_Reevaluate:
+		return "reevaluate"
+	case ClockJumpPolicy_FireImmediately:
+		return "fire-immediately"
+	case ClockJumpPolicy_Ignore:
+		return "ignore"
+	default:
+		return "unknown"
+	}
+}
+
+// WithClockJumpPolicy set the policy of the absolute-time tasks when the wall clock jumps,
+// default ClockJumpPolicy_Reevaluate.
+func WithClockJumpPolicy(p ClockJumpPolicy) Option {
+	return func(t *Timer) {
+		t.clockJumpPolicy = p
+	}
+}
+
+// clock the time base of the timer, the time is the wall clock captured at creation
+// plus the elapsed monotonic time, so it never goes backwards.
+type clock struct {
+	base     time.Time        // the creation time, with the monotonic clock reading.
+	baseNs   int64            // the wall clock of base, unix nanoseconds.
+	wallNow  func() time.Time // the wall clock.
+	offsetMs atomic.Int64     // the offset between the wall clock and the clock at last check, unit is milliseconds.
+}
+
+func newClock() *clock {
+	now := time.Now()
+	return &clock{
+		base:    now,
+		baseNs:  now.UnixNano(),
+		wallNow: time.Now,
+	}
+}
+
+// processClock the clock captured at package initialization, used by the standalone spokes.
+var processClock = newClock()
+
+// nowMs returns the current time of the clock, unix milliseconds.
+func (c *clock) nowMs() int64 {
+	return (c.baseNs + int64(time.Since(c.base))) / int64(time.Millisecond)
+}
+
+// expirationMs returns the expiration time of the task added at nowMs, unix milliseconds.
+// NOTE: the jitter is not included, see Timer.expirationMs.
+func (c *clock) expirationMs(task *Task, nowMs int64) int64 {
+	if atMs := task.atMs.Load(); atMs != 0 {
+		// translate the absolute wall clock time to the clock.
+		return nowMs + atMs - c.wallNow().UnixMilli()
+	}
+	return nowMs + int64(task.Delay()/time.Millisecond)
+}
+
+// jumped reports whether the wall clock jumped since last check.
+func (c *clock) jumped() bool {
+	offsetMs := c.wallNow().UnixMilli() - c.nowMs()
+	deltaMs := offsetMs - c.offsetMs.Swap(offsetMs)
+	return deltaMs >= clockJumpThresholdMs || deltaMs <= -clockJumpThresholdMs
+}
+
+// resetJump reset the offset as the baseline of the next check, the wall clock changed before is not a jump.
+func (c *clock) resetJump() {
+	c.offsetMs.Store(c.wallNow().UnixMilli() - c.nowMs())
+}
+
+// ClockJumps return the total number of wall clock jumps detected.
+// NOTE: only detected while there are absolute-time tasks pending, and the policy is not ClockJumpPolicy_Ignore.
+func (t *Timer) ClockJumps() int64 { return t.clockJumps.Load() }
+
+// watchAbsoluteTask watch the wall clock jumps for the absolute-time task.
+// NOTE: should be call when `Timer.rw` lock.
+func (t *Timer) watchAbsoluteTask(task *Task) {
+	if t.clockJumpPolicy == ClockJumpPolicy_Ignore {
+		return
+	}
+	t.absoluteMu.Lock()
+	t.absoluteTasks[task] = struct{}{}
+	t.absoluteMu.Unlock()
+	if !t.clockWatch.Activated() {
+		// the watcher is stopped while no absolute-time task pending, the wall clock is not watched meanwhile.
+		t.clock.resetJump()
+		t.addTaskEntry(t.newTaskEntry(t.clockWatch, t.clock.nowMs()))
+	}
+}
+
+// checkClock check the wall clock jump, and apply the policy to the pending absolute-time tasks.
+func (t *Timer) checkClock() {
+	jumped := t.clock.jumped()
+	t.absoluteMu.Lock()
+	tasks := make([]*Task, 0, len(t.absoluteTasks))
+	for task := range t.absoluteTasks {
+		if task.atMs.Load() == 0 || !task.Activated() {
+			delete(t.absoluteTasks, task)
+		} else {
+			tasks = append(tasks, task)
+		}
+	}
+	t.absoluteMu.Unlock()
+	if len(tasks) == 0 {
+		return
+	}
+	if jumped {
+		t.clockJumps.Add(1)
+		for _, task := range tasks {
+			t.rescheduleActiveTask(task, t.clockJumpPolicy == ClockJumpPolicy_FireImmediately)
+		}
+	}
+	_ = t.AddTask(t.clockWatch)
+}
+
+// rescheduleActiveTask re-evaluate the expiration of the task against the wall clock with its original jitter,
+// or fire it immediately if fireNow, when it is still pending.
+// it never runs a task twice, even if the task is expiring concurrently.
+func (t *Timer) rescheduleActiveTask(task *Task, fireNow bool) {
+	t.rw.RLock()
+	defer t.rw.RUnlock()
+	if t.closed {
+		return
+	}
+	task.rw.Lock()
+	old := task.taskEntry
+	if old == nil || !old.activated() {
+		task.rw.Unlock()
+		return
+	}
+	var expirationMs int64
+	if !fireNow {
+		expirationMs = t.clock.expirationMs(task, t.clock.nowMs()) + old.jitterMs
+	}
+	te := acquireTaskEntry(task, expirationMs)
+	te.seq = old.seq
+	te.jitterMs = old.jitterMs
+	// NOTE: the old task entry is cancelled once the task belongs to the new one,
+	// even if it is being flushed.
+	task.taskEntry = te
+	task.rw.Unlock()
+	// NOTE: remove without the task lock, see Task.cancel.
+	if old.remove() {
+		old.release()
+	} else {
+		old.drop()
+	}
+	t.addTaskEntry(te)
+}
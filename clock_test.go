@@ -0,0 +1,204 @@
+package timer
+
+import (
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Clock(t *testing.T) {
+	var skew atomic.Int64
+	c := newClock()
+	c.wallNow = func() time.Time { return time.Now().Add(time.Duration(skew.Load())) }
+
+	require.InDelta(t, time.Now().UnixMilli(), c.nowMs(), 5)
+	require.False(t, c.jumped())
+
+	task := NewTask(time.Second)
+	require.InDelta(t, c.nowMs()+1000, c.expirationMs(task, c.nowMs()), 5)
+	at := time.Now().Add(time.Minute)
+	require.InDelta(t, at.UnixMilli(), c.expirationMs(task.SetAt(at), c.nowMs()), 5)
+
+	// the wall clock jumps, the clock keeps going.
+	skew.Store(int64(time.Hour))
+	require.InDelta(t, time.Now().UnixMilli(), c.nowMs(), 5)
+	require.InDelta(t, at.UnixMilli()-time.Hour.Milliseconds(), c.expirationMs(task, c.nowMs()), 5)
+	require.True(t, c.jumped())
+	require.False(t, c.jumped())
+	skew.Store(int64(-time.Hour))
+	require.True(t, c.jumped())
+}
+
+func Test_ClockJumpPolicy_String(t *testing.T) {
+	require.Equal(t, "reevaluate", ClockJumpPolicy_Reevaluate.String())
+	require.Equal(t, "fire-immediately", ClockJumpPolicy_FireImmediately.String())
+	require.Equal(t, "ignore", ClockJumpPolicy_Ignore.String())
+	require.Equal(t, "unknown", ClockJumpPolicy(100).String())
+}
+
+func Test_Timer_AtFunc(t *testing.T) {
+	tm := NewTimer()
+	_, err := tm.AtFunc(time.Now(), func() {})
+	require.ErrorIs(t, err, ErrClosed)
+	tm.Start()
+	defer tm.Stop()
+
+	fired := make(chan time.Time, 1)
+	at := time.Now().Add(30 * time.Millisecond)
+	task, err := tm.AtFunc(at, func() { fired <- time.Now() })
+	require.NoError(t, err)
+	require.Equal(t, at.UnixMilli(), task.At().UnixMilli())
+	require.GreaterOrEqual(t, (<-fired).UnixMilli(), at.UnixMilli())
+
+	// becomes a relative-delay task.
+	require.True(t, task.SetDelay(time.Second).At().IsZero())
+}
+
+func Test_Timer_ClockJump(t *testing.T) {
+	newJumpTimer := func(policy ClockJumpPolicy) (*Timer, *atomic.Int64) {
+		var skew atomic.Int64
+		tm := NewTimer(WithClockJumpPolicy(policy))
+		tm.clock.wallNow = func() time.Time { return time.Now().Add(time.Duration(skew.Load())) }
+		tm.clockWatch.SetDelay(5 * time.Millisecond)
+		tm.Start()
+		return tm, &skew
+	}
+	fireAt := func(tm *Timer, at time.Time) (*Task, chan struct{}) {
+		fired := make(chan struct{})
+		task, err := tm.AtFunc(at, func() { close(fired) })
+		require.NoError(t, err)
+		return task, fired
+	}
+
+	t.Run("reevaluate forward", func(t *testing.T) {
+		tm, skew := newJumpTimer(ClockJumpPolicy_Reevaluate)
+		defer tm.Stop()
+
+		_, passed := fireAt(tm, time.Now().Add(30*time.Minute))
+		_, notDue := fireAt(tm, time.Now().Add(2*time.Hour))
+		skew.Store(int64(time.Hour))
+		<-passed
+		require.Equal(t, int64(1), tm.ClockJumps())
+		select {
+		case <-notDue:
+			t.Fatal("the task not yet due should be kept")
+		case <-time.After(20 * time.Millisecond):
+		}
+	})
+
+	t.Run("reevaluate backward", func(t *testing.T) {
+		tm, skew := newJumpTimer(ClockJumpPolicy_Reevaluate)
+		defer tm.Stop()
+
+		relative := make(chan struct{})
+		_, err := tm.AfterFunc(50*time.Millisecond, func() { close(relative) })
+		require.NoError(t, err)
+		_, absolute := fireAt(tm, time.Now().Add(50*time.Millisecond))
+		// the absolute-time task is postponed, the relative-delay task is not affected.
+		skew.Store(int64(-time.Hour))
+		<-relative
+		select {
+		case <-absolute:
+			t.Fatal("the absolute-time task should be postponed")
+		case <-time.After(50 * time.Millisecond):
+		}
+		require.Equal(t, int64(1), tm.ClockJumps())
+	})
+
+	t.Run("fire immediately", func(t *testing.T) {
+		tm, skew := newJumpTimer(ClockJumpPolicy_FireImmediately)
+		defer tm.Stop()
+
+		_, fired := fireAt(tm, time.Now().Add(10*time.Hour))
+		skew.Store(int64(-time.Hour))
+		<-fired
+		require.Equal(t, int64(1), tm.ClockJumps())
+	})
+
+	t.Run("changed while idle", func(t *testing.T) {
+		tm, skew := newJumpTimer(ClockJumpPolicy_FireImmediately)
+		defer tm.Stop()
+
+		// the wall clock changed while no absolute-time task pending is not a jump.
+		skew.Store(int64(time.Hour))
+		_, fired := fireAt(tm, time.Now().Add(time.Hour+30*time.Minute))
+		select {
+		case <-fired:
+			t.Fatal("the task should not fire")
+		case <-time.After(50 * time.Millisecond):
+		}
+		require.Zero(t, tm.ClockJumps())
+	})
+
+	t.Run("ignore", func(t *testing.T) {
+		tm, skew := newJumpTimer(ClockJumpPolicy_Ignore)
+		defer tm.Stop()
+
+		_, fired := fireAt(tm, time.Now().Add(50*time.Millisecond))
+		skew.Store(int64(time.Hour))
+		select {
+		case <-fired:
+			t.Fatal("the task should keep the expiration")
+		case <-time.After(20 * time.Millisecond):
+		}
+		<-fired
+		require.Zero(t, tm.ClockJumps())
+	})
+}
+
+func Test_Timer_ClockWatch(t *testing.T) {
+	tm := NewTimer(WithSpreading(0.5))
+	tm.Start()
+	defer tm.Stop()
+
+	task, err := tm.AtFunc(time.Now().Add(time.Hour), func() {})
+	require.NoError(t, err)
+	require.True(t, tm.clockWatch.Activated())
+	// the clock watcher is scheduled without the jitter, and hidden from the introspection.
+	tm.clockWatch.rw.RLock()
+	require.Zero(t, tm.clockWatch.taskEntry.jitterMs)
+	tm.clockWatch.rw.RUnlock()
+	require.Equal(t, int64(1), tm.TaskCounter())
+	require.Equal(t, []*Task{task}, slices.Collect(tm.Pending()))
+	n := 0
+	for _, wl := range tm.Levels() {
+		for _, sp := range wl.Spokes {
+			n += sp.Tasks
+		}
+	}
+	require.Equal(t, 1, n)
+}
+
+func Test_Timer_ClockJump_KeepJitter(t *testing.T) {
+	var skew atomic.Int64
+	tm := NewTimer(WithSpreading(0.5))
+	tm.clock.wallNow = func() time.Time { return time.Now().Add(time.Duration(skew.Load())) }
+	tm.clockWatch.SetDelay(5 * time.Millisecond)
+	tm.Start()
+	defer tm.Stop()
+
+	entry := func(task *Task) (int64, int64) {
+		task.rw.RLock()
+		defer task.rw.RUnlock()
+		return task.taskEntry.expirationMs, task.taskEntry.jitterMs
+	}
+	var task *Task
+	var expirationMs, jitterMs int64
+	// the jitter may be zero by chance.
+	for jitterMs == 0 {
+		var err error
+		task, err = tm.AtFunc(time.Now().Add(time.Hour), func() {})
+		require.NoError(t, err)
+		expirationMs, jitterMs = entry(task)
+	}
+	skew.Store(int64(30 * time.Minute))
+	require.Eventually(t, func() bool { return tm.ClockJumps() == 1 }, time.Second, time.Millisecond)
+	require.Eventually(t, func() bool {
+		newExpirationMs, newJitterMs := entry(task)
+		wantMs := expirationMs - (30 * time.Minute).Milliseconds()
+		return newJitterMs == jitterMs && newExpirationMs > wantMs-100 && newExpirationMs < wantMs+100
+	}, time.Second, time.Millisecond)
+}
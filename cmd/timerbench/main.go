@@ -0,0 +1,51 @@
+// Command timerbench drives a timer.Timer with a configurable workload
+// and reports throughput, lateness, allocations and cascade counts,
+// so different WithTickMs/WithWheelSize configurations can be compared.
+//
+// Usage:
+//
+//	timerbench -tick 1 -wheel 128 -tasks 100000 -dist exp -mean 500ms -cancel 0.1 -churn 0.2 -format csv
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+)
+
+func main() {
+	cfg := Config{}
+	var (
+		format string
+		header bool
+	)
+	flag.StringVar(&cfg.Label, "label", "", "label of this run, included in the report")
+	flag.Int64Var(&cfg.TickMs, "tick", 1, "basic time tick milliseconds of the timer")
+	flag.IntVar(&cfg.WheelSize, "wheel", 128, "wheel size of the timer")
+	flag.IntVar(&cfg.Tasks, "tasks", 100000, "total number of tasks to add")
+	flag.IntVar(&cfg.Workers, "workers", runtime.GOMAXPROCS(0), "number of goroutines adding tasks")
+	flag.IntVar(&cfg.Rate, "rate", 0, "tasks added per second in total, 0 means as fast as possible")
+	flag.StringVar(&cfg.Dist, "dist", DistUniform, "delay distribution, uniform or exp")
+	flag.DurationVar(&cfg.MinDelay, "min", 0, "minimum delay of uniform distribution")
+	flag.DurationVar(&cfg.MaxDelay, "max", time.Second, "maximum delay of uniform distribution, also the cap of exp distribution")
+	flag.DurationVar(&cfg.MeanDelay, "mean", 200*time.Millisecond, "mean delay of exp distribution")
+	flag.Float64Var(&cfg.CancelRatio, "cancel", 0, "ratio of tasks canceled after added, [0, 1]")
+	flag.Float64Var(&cfg.ChurnRatio, "churn", 0, "ratio of tasks rescheduled with a new delay after added, [0, 1]")
+	flag.Uint64Var(&cfg.Seed, "seed", 1, "random seed of the workload")
+	flag.StringVar(&format, "format", FormatText, "report format, text, csv or json")
+	flag.BoolVar(&header, "header", true, "write csv header")
+	flag.Parse()
+
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "timerbench: %v\n", err)
+		flag.Usage()
+		os.Exit(2)
+	}
+	result := Run(cfg)
+	if err := WriteReport(os.Stdout, format, header, result); err != nil {
+		fmt.Fprintf(os.Stderr, "timerbench: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// report formats.
+const (
+	FormatText = "text"
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+var csvHeader = []string{
+	"label", "tick_ms", "wheel_size", "tasks", "workers", "rate", "dist",
+	"min_delay_ms", "max_delay_ms", "mean_delay_ms", "cancel_ratio", "churn_ratio", "seed",
+	"added", "canceled", "rescheduled", "fired", "missing",
+	"elapsed_ms", "add_elapsed_ms", "add_throughput",
+	"lateness_p50_ms", "lateness_p99_ms", "lateness_max_ms",
+	"allocs_per_task", "bytes_per_task", "cascades",
+}
+
+// WriteReport writes the result in the format, header only used by csv format.
+func WriteReport(w io.Writer, format string, header bool, r Result) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if header {
+			if err := cw.Write(csvHeader); err != nil {
+				return err
+			}
+		}
+		if err := cw.Write(csvRecord(r)); err != nil {
+			return err
+		}
+		cw.Flush()
+		return cw.Error()
+	case FormatText:
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(tw, "label\t%s\n", r.Label)
+		fmt.Fprintf(tw, "timer\ttickMs=%d wheelSize=%d\n", r.TickMs, r.WheelSize)
+		fmt.Fprintf(tw, "workload\ttasks=%d workers=%d rate=%d dist=%s min=%s max=%s mean=%s cancel=%g churn=%g seed=%d\n",
+			r.Tasks, r.Workers, r.Rate, r.Dist, r.MinDelay, r.MaxDelay, r.MeanDelay, r.CancelRatio, r.ChurnRatio, r.Seed)
+		fmt.Fprintf(tw, "tasks\tadded=%d canceled=%d rescheduled=%d fired=%d missing=%d\n",
+			r.Added, r.Canceled, r.Rescheduled, r.Fired, r.Missing)
+		fmt.Fprintf(tw, "elapsed\t%s (adding %s)\n", r.Elapsed, r.AddElapsed)
+		fmt.Fprintf(tw, "throughput\t%.0f ops/s\n", r.AddThroughput)
+		fmt.Fprintf(tw, "lateness\tp50=%s p99=%s max=%s\n", r.LatenessP50, r.LatenessP99, r.LatenessMax)
+		fmt.Fprintf(tw, "allocations\t%.2f allocs/task %.0f B/task\n", r.AllocsPerTask, r.BytesPerTask)
+		fmt.Fprintf(tw, "cascades\t%d\n", r.Cascades)
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		return writeHistogram(w, r.Histogram)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// writeHistogram writes the lateness histogram as horizontal bars.
+func writeHistogram(w io.Writer, buckets []Bucket) error {
+	const width = 50
+
+	total, peak := 0, 0
+	for _, b := range buckets {
+		total += b.Count
+		peak = max(peak, b.Count)
+	}
+	if total == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintln(w, "lateness histogram:"); err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 1, ' ', tabwriter.AlignRight)
+	for _, b := range buckets {
+		var name string
+		switch {
+		case b.Lower == math.MinInt64:
+			name = "< " + b.Upper.String()
+		case b.Upper == 0 && b.Lower > 0:
+			name = ">= " + b.Lower.String()
+		default:
+			name = b.Lower.String() + " - " + b.Upper.String()
+		}
+		bar := strings.Repeat("#", b.Count*width/peak)
+		fmt.Fprintf(tw, "%s\t%d\t%5.1f%%\t %s\n", name, b.Count, float64(b.Count)*100/float64(total), bar)
+	}
+	return tw.Flush()
+}
+
+func csvRecord(r Result) []string {
+	return []string{
+		r.Label,
+		strconv.FormatInt(r.TickMs, 10),
+		strconv.Itoa(r.WheelSize),
+		strconv.Itoa(r.Tasks),
+		strconv.Itoa(r.Workers),
+		strconv.Itoa(r.Rate),
+		r.Dist,
+		formatMs(r.MinDelay),
+		formatMs(r.MaxDelay),
+		formatMs(r.MeanDelay),
+		strconv.FormatFloat(r.CancelRatio, 'g', -1, 64),
+		strconv.FormatFloat(r.ChurnRatio, 'g', -1, 64),
+		strconv.FormatUint(r.Seed, 10),
+		strconv.FormatInt(r.Added, 10),
+		strconv.FormatInt(r.Canceled, 10),
+		strconv.FormatInt(r.Rescheduled, 10),
+		strconv.FormatInt(r.Fired, 10),
+		strconv.FormatInt(r.Missing, 10),
+		formatMs(r.Elapsed),
+		formatMs(r.AddElapsed),
+		strconv.FormatFloat(r.AddThroughput, 'f', 0, 64),
+		formatMs(r.LatenessP50),
+		formatMs(r.LatenessP99),
+		formatMs(r.LatenessMax),
+		strconv.FormatFloat(r.AllocsPerTask, 'f', 2, 64),
+		strconv.FormatFloat(r.BytesPerTask, 'f', 0, 64),
+		strconv.FormatInt(r.Cascades, 10),
+	}
+}
+
+func formatMs(d time.Duration) string {
+	return strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', 3, 64)
+}
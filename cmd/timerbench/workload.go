@@ -0,0 +1,268 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/thinkgos/timer"
+)
+
+// delay distributions.
+const (
+	DistUniform = "uniform"
+	DistExp     = "exp"
+)
+
+// Config the workload configuration.
+type Config struct {
+	Label       string        `json:"label"`
+	TickMs      int64         `json:"tickMs"`
+	WheelSize   int           `json:"wheelSize"`
+	Tasks       int           `json:"tasks"`
+	Workers     int           `json:"workers"`
+	Rate        int           `json:"rate"`
+	Dist        string        `json:"dist"`
+	MinDelay    time.Duration `json:"minDelay"`
+	MaxDelay    time.Duration `json:"maxDelay"`
+	MeanDelay   time.Duration `json:"meanDelay"`
+	CancelRatio float64       `json:"cancelRatio"`
+	ChurnRatio  float64       `json:"churnRatio"`
+	Seed        uint64        `json:"seed"`
+}
+
+// Validate the configuration.
+func (c *Config) Validate() error {
+	switch {
+	case c.TickMs <= 0:
+		return errors.New("tick must be greater than 0")
+	case c.WheelSize <= 0:
+		return errors.New("wheel size must be greater than 0")
+	case c.Tasks <= 0:
+		return errors.New("tasks must be greater than 0")
+	case c.Workers <= 0:
+		return errors.New("workers must be greater than 0")
+	case c.Rate < 0:
+		return errors.New("rate must be greater than or equal to 0")
+	case c.Dist != DistUniform && c.Dist != DistExp:
+		return errors.New("dist must be uniform or exp")
+	case c.MinDelay < 0 || c.MaxDelay < c.MinDelay:
+		return errors.New("delay range must be 0 <= min <= max")
+	case c.Dist == DistExp && c.MeanDelay <= 0:
+		return errors.New("mean delay must be greater than 0")
+	case c.CancelRatio < 0 || c.CancelRatio > 1:
+		return errors.New("cancel ratio must be in [0, 1]")
+	case c.ChurnRatio < 0 || c.ChurnRatio > 1:
+		return errors.New("churn ratio must be in [0, 1]")
+	}
+	return nil
+}
+
+// Result the benchmark result.
+type Result struct {
+	Config
+	Added         int64         `json:"added"`
+	Canceled      int64         `json:"canceled"`
+	Rescheduled   int64         `json:"rescheduled"`
+	Fired         int64         `json:"fired"`   // the number of job executions.
+	Missing       int64         `json:"missing"` // expected to fire but not fired before the deadline.
+	Elapsed       time.Duration `json:"elapsed"`
+	AddElapsed    time.Duration `json:"addElapsed"`
+	AddThroughput float64       `json:"addThroughput"` // add, cancel and reschedule operations per second.
+	LatenessP50   time.Duration `json:"latenessP50"`
+	LatenessP99   time.Duration `json:"latenessP99"`
+	LatenessMax   time.Duration `json:"latenessMax"`
+	AllocsPerTask float64       `json:"allocsPerTask"`
+	BytesPerTask  float64       `json:"bytesPerTask"`
+	Cascades      int64         `json:"cascades"`
+	Histogram     []Bucket      `json:"histogram"` // lateness histogram.
+}
+
+// Bucket a lateness histogram bucket, [Lower, Upper).
+type Bucket struct {
+	Lower time.Duration `json:"lower"`
+	Upper time.Duration `json:"upper"` // 0 means unbounded.
+	Count int           `json:"count"`
+}
+
+// histogramBounds the upper bounds of the lateness histogram buckets.
+var histogramBounds = []time.Duration{
+	0,
+	time.Millisecond,
+	2 * time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+}
+
+// job records the lateness when fired.
+type job struct {
+	target   atomic.Int64 // the target fire time, unix nanoseconds.
+	lateness *atomic.Int64
+	fired    *atomic.Int64
+}
+
+func (j *job) Run() {
+	j.lateness.Store(time.Now().UnixNano() - j.target.Load())
+	j.fired.Add(1)
+}
+
+// notFired the lateness sentinel of the task which not fired.
+const notFired = math.MinInt64
+
+// Run the workload and return the result.
+func Run(cfg Config) Result {
+	tm := timer.NewTimer(timer.WithTickMs(cfg.TickMs), timer.WithWheelSize(cfg.WheelSize))
+	tm.Start()
+	defer tm.Stop()
+
+	var (
+		fired       atomic.Int64
+		canceled    atomic.Int64
+		rescheduled atomic.Int64
+		maxTarget   atomic.Int64
+		lateness    = make([]atomic.Int64, cfg.Tasks)
+		wg          sync.WaitGroup
+	)
+	for i := range lateness {
+		lateness[i].Store(notFired)
+	}
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	start := time.Now()
+	for w := 0; w < cfg.Workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			rnd := rand.New(rand.NewPCG(cfg.Seed, uint64(w)))
+			var interval time.Duration
+			if cfg.Rate > 0 {
+				interval = time.Duration(int64(time.Second) * int64(cfg.Workers) / int64(cfg.Rate))
+			}
+			workerStart := time.Now()
+			for i, n := w, 0; i < cfg.Tasks; i, n = i+cfg.Workers, n+1 {
+				if interval > 0 {
+					if d := time.Until(workerStart.Add(time.Duration(n) * interval)); d > 0 {
+						time.Sleep(d)
+					}
+				}
+				j := &job{lateness: &lateness[i], fired: &fired}
+				task := timer.NewTaskJob(cfg.delay(rnd), j)
+				j.target.Store(time.Now().Add(task.Delay()).UnixNano())
+				_ = tm.AddTask(task)
+				switch {
+				case cfg.CancelRatio > 0 && rnd.Float64() < cfg.CancelRatio:
+					task.Cancel()
+					canceled.Add(1)
+					continue
+				case cfg.ChurnRatio > 0 && rnd.Float64() < cfg.ChurnRatio:
+					task.SetDelay(cfg.delay(rnd))
+					j.target.Store(time.Now().Add(task.Delay()).UnixNano())
+					_ = tm.AddTask(task)
+					rescheduled.Add(1)
+				}
+				storeMax(&maxTarget, j.target.Load())
+			}
+		}(w)
+	}
+	wg.Wait()
+	addElapsed := time.Since(start)
+
+	// wait all the tasks expected fired, or the deadline.
+	expected := int64(cfg.Tasks) - canceled.Load()
+	deadline := time.Unix(0, maxTarget.Load()).Add(time.Second + 2*time.Duration(cfg.TickMs)*time.Millisecond)
+	for fired.Load() < expected && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	result := Result{
+		Config:      cfg,
+		Added:       int64(cfg.Tasks),
+		Canceled:    canceled.Load(),
+		Rescheduled: rescheduled.Load(),
+		Fired:       fired.Load(),
+		Missing:     max(expected-fired.Load(), 0),
+		Elapsed:     elapsed,
+		AddElapsed:  addElapsed,
+		Cascades:    tm.CascadeCounter(),
+	}
+	ops := float64(result.Added + result.Canceled + result.Rescheduled)
+	if secs := addElapsed.Seconds(); secs > 0 {
+		result.AddThroughput = ops / secs
+	}
+	result.AllocsPerTask = float64(after.Mallocs-before.Mallocs) / float64(cfg.Tasks)
+	result.BytesPerTask = float64(after.TotalAlloc-before.TotalAlloc) / float64(cfg.Tasks)
+
+	samples := make([]int64, 0, result.Fired)
+	for i := range lateness {
+		if v := lateness[i].Load(); v != notFired {
+			samples = append(samples, v)
+		}
+	}
+	slices.Sort(samples)
+	result.LatenessP50 = time.Duration(percentile(samples, 0.50))
+	result.LatenessP99 = time.Duration(percentile(samples, 0.99))
+	if len(samples) > 0 {
+		result.LatenessMax = time.Duration(samples[len(samples)-1])
+	}
+	result.Histogram = histogram(samples)
+	return result
+}
+
+// histogram of the sorted samples.
+func histogram(sorted []int64) []Bucket {
+	buckets := make([]Bucket, 0, len(histogramBounds)+1)
+	lower := time.Duration(math.MinInt64)
+	for _, upper := range histogramBounds {
+		buckets = append(buckets, Bucket{Lower: lower, Upper: upper})
+		lower = upper
+	}
+	buckets = append(buckets, Bucket{Lower: lower})
+	i := 0
+	for _, v := range sorted {
+		for i < len(histogramBounds) && time.Duration(v) >= histogramBounds[i] {
+			i++
+		}
+		buckets[i].Count++
+	}
+	return buckets
+}
+
+// delay generates a delay of the distribution.
+func (c *Config) delay(rnd *rand.Rand) time.Duration {
+	switch c.Dist {
+	case DistExp:
+		d := time.Duration(rnd.ExpFloat64() * float64(c.MeanDelay))
+		if c.MaxDelay > 0 && d > c.MaxDelay {
+			d = c.MaxDelay
+		}
+		return d
+	default:
+		return c.MinDelay + time.Duration(rnd.Int64N(int64(c.MaxDelay-c.MinDelay)+1))
+	}
+}
+
+// percentile of the sorted samples, p in [0, 1].
+func percentile(sorted []int64, p float64) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[min(max(idx, 0), len(sorted)-1)]
+}
+
+func storeMax(v *atomic.Int64, n int64) {
+	for old := v.Load(); n > old && !v.CompareAndSwap(old, n); old = v.Load() {
+	}
+}
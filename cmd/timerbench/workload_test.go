@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Config_Validate(t *testing.T) {
+	valid := Config{
+		TickMs:    1,
+		WheelSize: 16,
+		Tasks:     1,
+		Workers:   1,
+		Dist:      DistUniform,
+		MaxDelay:  time.Second,
+		MeanDelay: time.Second,
+	}
+	require.NoError(t, valid.Validate())
+
+	for _, f := range []func(*Config){
+		func(c *Config) { c.TickMs = 0 },
+		func(c *Config) { c.WheelSize = 0 },
+		func(c *Config) { c.Tasks = 0 },
+		func(c *Config) { c.Workers = 0 },
+		func(c *Config) { c.Rate = -1 },
+		func(c *Config) { c.Dist = "normal" },
+		func(c *Config) { c.MinDelay = 2 * time.Second },
+		func(c *Config) { c.Dist, c.MeanDelay = DistExp, 0 },
+		func(c *Config) { c.CancelRatio = 2 },
+		func(c *Config) { c.ChurnRatio = -1 },
+	} {
+		c := valid
+		f(&c)
+		require.Error(t, c.Validate())
+	}
+}
+
+func Test_Run(t *testing.T) {
+	for _, dist := range []string{DistUniform, DistExp} {
+		r := Run(Config{
+			TickMs:      1,
+			WheelSize:   16,
+			Tasks:       500,
+			Workers:     2,
+			Rate:        10000,
+			Dist:        dist,
+			MinDelay:    10 * time.Millisecond,
+			MaxDelay:    100 * time.Millisecond,
+			MeanDelay:   20 * time.Millisecond,
+			CancelRatio: 0.2,
+			ChurnRatio:  0.2,
+			Seed:        1,
+		})
+		require.Equal(t, int64(500), r.Added)
+		require.Greater(t, r.Canceled, int64(0))
+		require.Greater(t, r.Rescheduled, int64(0))
+		require.Zero(t, r.Missing)
+		require.GreaterOrEqual(t, r.Fired, r.Added-r.Canceled)
+		require.LessOrEqual(t, r.LatenessP50, r.LatenessP99)
+		require.LessOrEqual(t, r.LatenessP99, r.LatenessMax)
+		total := 0
+		for _, b := range r.Histogram {
+			total += b.Count
+		}
+		require.LessOrEqual(t, total, int(r.Fired)) // a task may fire before it is rescheduled.
+
+		for _, format := range []string{FormatText, FormatCSV, FormatJSON} {
+			buf := &bytes.Buffer{}
+			require.NoError(t, WriteReport(buf, format, true, r))
+			require.NotEmpty(t, buf.String())
+		}
+	}
+}
+
+func Test_WriteReport(t *testing.T) {
+	r := Result{Config: Config{Label: "x", TickMs: 1, WheelSize: 16}, Added: 1, Fired: 1}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, WriteReport(buf, FormatCSV, true, r))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	require.Equal(t, len(csvHeader), len(strings.Split(lines[1], ",")))
+
+	buf.Reset()
+	require.NoError(t, WriteReport(buf, FormatCSV, false, r))
+	require.Equal(t, 1, strings.Count(buf.String(), "\n"))
+
+	buf.Reset()
+	require.NoError(t, WriteReport(buf, FormatJSON, false, r))
+	var got Result
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	require.Equal(t, r.Label, got.Label)
+
+	require.Error(t, WriteReport(buf, "xml", false, r))
+}
+
+func Test_Percentile(t *testing.T) {
+	require.Zero(t, percentile(nil, 0.5))
+	samples := []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	require.Equal(t, int64(5), percentile(samples, 0.5))
+	require.Equal(t, int64(10), percentile(samples, 0.99))
+	require.Equal(t, int64(1), percentile(samples, 0))
+}
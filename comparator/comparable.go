@@ -1,11 +1,92 @@
 package comparator
 
-import "time"
+import (
+	"cmp"
+	"time"
+)
 
 // Comparable method CompareTo(Comparable) should return a negative number when v1 < v2,
 // a positive number when v1 > v2 and zero when v1 == v2.
 type Comparable[T any] func(T, T) int
 
+// OrderedFunc return a Comparable for any ordered type, same as cmp.Compare.
+func OrderedFunc[T cmp.Ordered]() Comparable[T] {
+	return cmp.Compare[T]
+}
+
+// Reverse return a Comparable which reverse the order of c.
+func Reverse[T any](c Comparable[T]) Comparable[T] {
+	return func(v1, v2 T) int {
+		return c(v2, v1)
+	}
+}
+
+// By return a Comparable which compares the key extracted from the value.
+func By[T any, K cmp.Ordered](key func(T) K) Comparable[T] {
+	return func(v1, v2 T) int {
+		return cmp.Compare(key(v1), key(v2))
+	}
+}
+
+// Then return a Comparable which compares with first,
+// if they are equal, then compares with second.
+func Then[T any](first, second Comparable[T]) Comparable[T] {
+	return func(v1, v2 T) int {
+		if c := first(v1, v2); c != 0 {
+			return c
+		}
+		return second(v1, v2)
+	}
+}
+
+// Chain return a Comparable which compares with each Comparable in order,
+// the first non-zero result is returned. multi-key ordering.
+func Chain[T any](cs ...Comparable[T]) Comparable[T] {
+	return func(v1, v2 T) int {
+		for _, c := range cs {
+			if r := c(v1, v2); r != 0 {
+				return r
+			}
+		}
+		return 0
+	}
+}
+
+// NilsFirst return a Comparable for pointers which treat nil as less than non-nil,
+// non-nil pointers are compared with c.
+func NilsFirst[T any](c Comparable[*T]) Comparable[*T] {
+	return func(v1, v2 *T) int {
+		switch {
+		case v1 == nil && v2 == nil:
+			return 0
+		case v1 == nil:
+			return -1
+		case v2 == nil:
+			return 1
+		default:
+			return c(v1, v2)
+		}
+	}
+}
+
+// NilsLast return a Comparable for pointers which treat nil as greater than non-nil,
+// non-nil pointers are compared with c.
+func NilsLast[T any](c Comparable[*T]) Comparable[*T] {
+	return func(v1, v2 *T) int {
+		switch {
+		case v1 == nil && v2 == nil:
+			return 0
+		case v1 == nil:
+			return 1
+		case v2 == nil:
+			return -1
+		default:
+			return c(v1, v2)
+		}
+	}
+}
+
+// CompareTime compares two time.Time instances.
 func CompareTime(t1, t2 time.Time) int {
 	if t1.Before(t2) {
 		return -1
@@ -15,3 +96,8 @@ func CompareTime(t1, t2 time.Time) int {
 	}
 	return 0
 }
+
+// CompareDuration compares two time.Duration instances.
+func CompareDuration(d1, d2 time.Duration) int {
+	return cmp.Compare(d1, d2)
+}
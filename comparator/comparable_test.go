@@ -1,10 +1,14 @@
 package comparator
 
 import (
+	"cmp"
+	"slices"
+	"sort"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/thinkgos/timer/go/heap"
 )
 
 func Test_Comparable_Time(t *testing.T) {
@@ -15,3 +19,115 @@ func Test_Comparable_Time(t *testing.T) {
 	require.Equal(t, 0, CompareTime(v1, v1))
 	require.Equal(t, -1, CompareTime(v2, v1))
 }
+
+func Test_Comparable_Duration(t *testing.T) {
+	require.Equal(t, 1, CompareDuration(time.Minute, time.Second))
+	require.Equal(t, 0, CompareDuration(time.Second, time.Second))
+	require.Equal(t, -1, CompareDuration(time.Second, time.Minute))
+}
+
+func Test_Comparable_OrderedFunc_Reverse(t *testing.T) {
+	c := OrderedFunc[int]()
+	require.Equal(t, -1, c(1, 2))
+	require.Equal(t, 0, c(2, 2))
+	require.Equal(t, 1, c(3, 2))
+
+	r := Reverse(c)
+	require.Equal(t, 1, r(1, 2))
+	require.Equal(t, 0, r(2, 2))
+	require.Equal(t, -1, r(3, 2))
+}
+
+type testPerson struct {
+	name string
+	age  int
+}
+
+func Test_Comparable_By_Then_Chain(t *testing.T) {
+	items := []testPerson{
+		{"carol", 30},
+		{"alice", 30},
+		{"bob", 20},
+		{"dave", 20},
+		{"alice", 20},
+	}
+	byAge := By(func(p testPerson) int { return p.age })
+	byName := By(func(p testPerson) string { return p.name })
+
+	require.Equal(t, -1, byAge(items[2], items[0]))
+	require.Equal(t, 0, byAge(items[0], items[1]))
+
+	want := []testPerson{
+		{"alice", 20},
+		{"bob", 20},
+		{"dave", 20},
+		{"alice", 30},
+		{"carol", 30},
+	}
+	c1 := Container[testPerson]{
+		Items:   slices.Clone(items),
+		Compare: Then(byAge, byName),
+	}
+	sort.Sort(c1)
+	require.Equal(t, want, c1.Items)
+
+	c2 := Container[testPerson]{
+		Items:   slices.Clone(items),
+		Compare: Chain(byAge, byName),
+	}
+	sort.Sort(c2)
+	require.Equal(t, want, c2.Items)
+
+	// age desc, name asc
+	c3 := &Container[testPerson]{
+		Items:   slices.Clone(items),
+		Compare: Chain(Reverse(byAge), byName),
+	}
+	heap.Init[testPerson](c3)
+	for _, v := range []testPerson{
+		{"alice", 30},
+		{"carol", 30},
+		{"alice", 20},
+		{"bob", 20},
+		{"dave", 20},
+	} {
+		require.Equal(t, v, heap.Pop[testPerson](c3))
+	}
+	require.Zero(t, c3.Len())
+
+	// empty chain treat all values as equal.
+	require.Zero(t, Chain[testPerson]()(items[0], items[1]))
+}
+
+func Test_Comparable_Nils(t *testing.T) {
+	ptr := func(v int) *int { return &v }
+	deref := By(func(v *int) int { return *v })
+	items := []*int{ptr(3), nil, ptr(1), nil, ptr(2)}
+
+	c1 := &Container[*int]{
+		Items:   slices.Clone(items),
+		Compare: NilsFirst(deref),
+	}
+	heap.Init[*int](c1)
+	require.Nil(t, heap.Pop[*int](c1))
+	require.Nil(t, heap.Pop[*int](c1))
+	for _, v := range []int{1, 2, 3} {
+		require.Equal(t, v, *heap.Pop[*int](c1))
+	}
+	require.Zero(t, c1.Len())
+
+	c2 := Container[*int]{
+		Items:   slices.Clone(items),
+		Compare: NilsLast(deref),
+	}
+	sort.Sort(c2)
+	for i, v := range []int{1, 2, 3} {
+		require.Equal(t, v, *c2.Items[i])
+	}
+	require.Nil(t, c2.Items[3])
+	require.Nil(t, c2.Items[4])
+
+	require.Zero(t, NilsFirst(deref)(nil, nil))
+	require.Zero(t, NilsLast(deref)(nil, nil))
+	require.Equal(t, cmp.Compare(1, 2), NilsLast(deref)(ptr(1), ptr(2)))
+}
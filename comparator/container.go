@@ -1,6 +1,8 @@
 package comparator
 
 import (
+	"iter"
+	"slices"
 	"sort"
 
 	"github.com/thinkgos/timer/go/heap"
@@ -51,3 +53,32 @@ func (c *Container[T]) Pop() T {
 	c.Items = old[:n-1]
 	return x
 }
+
+// All returns an iterator over the items in the container, in the underlying order.
+// It is zero-copy, the container must not be modified during the iteration.
+func (c Container[T]) All() iter.Seq[T] {
+	return slices.Values(c.Items)
+}
+
+// Sorted returns an iterator over the items in the container, in sorted order.
+// It iterates over a sorted copy, the container is unchanged.
+func (c Container[T]) Sorted() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		sorted := c.Clone()
+		sort.Stable(sorted)
+		for _, v := range sorted.Items {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns a copy of the container, the items are shallow copied.
+func (c Container[T]) Clone() *Container[T] {
+	return &Container[T]{
+		Items:   slices.Clone(c.Items),
+		Desc:    c.Desc,
+		Compare: c.Compare,
+	}
+}
@@ -65,3 +65,38 @@ func Test_Container_Heap(t *testing.T) {
 	}
 	require.Zero(t, c2.Len())
 }
+
+func Test_Container_Iterator(t *testing.T) {
+	items := []int{5, 9, 3, 7, 10, 2, 6, 1, 8}
+
+	c := &Container[int]{
+		Items:   slices.Clone(items),
+		Desc:    false,
+		Compare: cmp.Compare[int],
+	}
+	heap.Init[int](c)
+	heapItems := slices.Clone(c.Items)
+
+	require.Equal(t, heapItems, slices.Collect(c.All()))
+	require.Equal(t, []int{1, 2, 3, 5, 6, 7, 8, 9, 10}, slices.Collect(c.Sorted()))
+	require.Equal(t, heapItems, c.Items) // unchanged
+	for v := range c.Sorted() {
+		require.Equal(t, 1, v)
+		break
+	}
+
+	c.Desc = true
+	require.Equal(t, []int{10, 9, 8, 7, 6, 5, 3, 2, 1}, slices.Collect(c.Sorted()))
+	c.Desc = false
+
+	// Clone
+	c2 := c.Clone()
+	require.Equal(t, c.Len(), c2.Len())
+	heap.Push(c2, 0)
+	require.Equal(t, heapItems, c.Items)
+	require.Equal(t, 0, heap.Pop(c2))
+	for _, v := range []int{1, 2, 3, 5, 6, 7, 8, 9, 10} {
+		require.Equal(t, v, heap.Pop(c2))
+	}
+	require.Equal(t, len(items), c.Len())
+}
@@ -0,0 +1,206 @@
+package timer
+
+import (
+	"sync"
+	"time"
+)
+
+// DebounceOption customize the Debouncer.
+type DebounceOption func(*debounceConfig)
+
+type debounceConfig struct {
+	leading  bool          // call on the leading edge of a burst.
+	trailing bool          // call on the trailing edge of a burst.
+	maxWait  time.Duration // the maximum time a call can be delayed since the burst started, 0 means no limit.
+}
+
+// WithLeading call on the leading edge of a burst, default false.
+func WithLeading(leading bool) DebounceOption {
+	return func(c *debounceConfig) { c.leading = leading }
+}
+
+// WithTrailing call on the trailing edge of a burst, default true.
+// if both leading and trailing are true, the trailing call only happens
+// if there are more triggers after the leading call.
+func WithTrailing(trailing bool) DebounceOption {
+	return func(c *debounceConfig) { c.trailing = trailing }
+}
+
+// WithMaxWait the maximum time a call can be delayed since the burst started, 0 means no limit.
+func WithMaxWait(maxWait time.Duration) DebounceOption {
+	return func(c *debounceConfig) { c.maxWait = maxWait }
+}
+
+// debounceState the state of a key in a burst.
+type debounceState struct {
+	task    *Task // the trailing edge task.
+	startMs int64 // the time the burst started, unit is milliseconds.
+	pending bool  // there are triggers not yet called.
+}
+
+// KeyedDebouncer coalesces bursts of triggers per key, and calls f once after
+// the key has been quiet for the wait duration.
+// Only keys in a burst hold state, which is a single task on the timing wheel,
+// so millions of keys stay cheap.
+type KeyedDebouncer[K comparable] struct {
+	timer  *Timer
+	wait   time.Duration
+	f      func(K)
+	config debounceConfig
+	mu     sync.Mutex           // protects following fields.
+	states map[K]*debounceState // the keys in a burst.
+}
+
+// NewKeyedDebouncer new keyed debouncer, f is called through the timer's `GoPool`.
+func NewKeyedDebouncer[K comparable](t *Timer, wait time.Duration, f func(K), opts ...DebounceOption) *KeyedDebouncer[K] {
+	d := &KeyedDebouncer[K]{
+		timer: t,
+		wait:  wait,
+		f:     f,
+		config: debounceConfig{
+			leading:  false,
+			trailing: true,
+			maxWait:  0,
+		},
+		states: make(map[K]*debounceState),
+	}
+	for _, opt := range opts {
+		opt(&d.config)
+	}
+	return d
+}
+
+// Trigger an event of the key.
+func (d *KeyedDebouncer[K]) Trigger(key K) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	nowMs := time.Now().UnixMilli()
+	s, inBurst := d.states[key]
+	if !inBurst {
+		s = &debounceState{startMs: nowMs}
+		s.task = NewTaskFunc(0, func() { d.expire(key, s) }).WithNoSkip().WithNoSpreading()
+	}
+	s.pending = inBurst || !d.config.leading
+
+	deadlineMs := nowMs + d.wait.Milliseconds()
+	if d.config.maxWait > 0 {
+		deadlineMs = min(deadlineMs, s.startMs+d.config.maxWait.Milliseconds())
+	}
+	err := d.timer.AddTask(s.task.SetDelay(time.Duration(deadlineMs-nowMs) * time.Millisecond))
+	if err != nil {
+		s.task.Cancel()
+		delete(d.states, key)
+		return err
+	}
+	if !inBurst {
+		d.states[key] = s
+		if d.config.leading {
+			d.call(key)
+		}
+	}
+	return nil
+}
+
+// Cancel the pending call of the key, and end the burst.
+func (d *KeyedDebouncer[K]) Cancel(key K) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if s, ok := d.states[key]; ok {
+		s.task.Cancel()
+		delete(d.states, key)
+	}
+}
+
+// Len returns the number of keys in a burst.
+func (d *KeyedDebouncer[K]) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.states)
+}
+
+func (d *KeyedDebouncer[K]) expire(key K, s *debounceState) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	// the burst has ended, or rescheduled by another trigger.
+	if d.states[key] != s || s.task.Activated() {
+		return
+	}
+	if s.pending && d.config.trailing {
+		d.call(key)
+		if d.config.leading {
+			// start a quiet window, avoid calling on the leading edge right after the trailing call.
+			s.startMs = time.Now().UnixMilli()
+			s.pending = false
+			if d.timer.AddTask(s.task.SetDelay(d.wait)) == nil {
+				return
+			}
+		}
+	}
+	delete(d.states, key)
+}
+
+// NOTE: should be call when `KeyedDebouncer.mu` lock.
+func (d *KeyedDebouncer[K]) call(key K) {
+	task := NewTaskFunc(0, func() { d.f(key) })
+	d.timer.dispatch(task, task)
+}
+
+// Debouncer coalesces bursts of triggers, and calls f once after it has been quiet for the wait duration.
+type Debouncer struct {
+	d *KeyedDebouncer[struct{}]
+}
+
+// NewDebouncer new debouncer, f is called through the timer's `GoPool`.
+func NewDebouncer(t *Timer, wait time.Duration, f func(), opts ...DebounceOption) *Debouncer {
+	return &Debouncer{
+		d: NewKeyedDebouncer(t, wait, func(struct{}) { f() }, opts...),
+	}
+}
+
+// Trigger an event.
+func (d *Debouncer) Trigger() error { return d.d.Trigger(struct{}{}) }
+
+// Cancel the pending call, and end the burst.
+func (d *Debouncer) Cancel() { d.d.Cancel(struct{}{}) }
+
+// KeyedThrottler calls f at most once per interval per key,
+// on the leading edge, and on the trailing edge if there are more triggers in the interval.
+type KeyedThrottler[K comparable] struct {
+	d *KeyedDebouncer[K]
+}
+
+// NewKeyedThrottler new keyed throttler, f is called through the timer's `GoPool`.
+func NewKeyedThrottler[K comparable](t *Timer, interval time.Duration, f func(K)) *KeyedThrottler[K] {
+	return &KeyedThrottler[K]{
+		d: NewKeyedDebouncer(t, interval, f, WithLeading(true), WithTrailing(true), WithMaxWait(interval)),
+	}
+}
+
+// Trigger an event of the key.
+func (t *KeyedThrottler[K]) Trigger(key K) error { return t.d.Trigger(key) }
+
+// Cancel the pending trailing call of the key.
+func (t *KeyedThrottler[K]) Cancel(key K) { t.d.Cancel(key) }
+
+// Len returns the number of keys in a throttle interval.
+func (t *KeyedThrottler[K]) Len() int { return t.d.Len() }
+
+// Throttler calls f at most once per interval,
+// on the leading edge, and on the trailing edge if there are more triggers in the interval.
+type Throttler struct {
+	t *KeyedThrottler[struct{}]
+}
+
+// NewThrottler new throttler, f is called through the timer's `GoPool`.
+func NewThrottler(t *Timer, interval time.Duration, f func()) *Throttler {
+	return &Throttler{
+		t: NewKeyedThrottler(t, interval, func(struct{}) { f() }),
+	}
+}
+
+// Trigger an event.
+func (t *Throttler) Trigger() error { return t.t.Trigger(struct{}{}) }
+
+// Cancel the pending trailing call.
+func (t *Throttler) Cancel() { t.t.Cancel(struct{}{}) }
@@ -0,0 +1,157 @@
+package timer
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Debouncer(t *testing.T) {
+	tm := NewTimer()
+
+	var calls atomic.Int64
+	d := NewDebouncer(tm, 30*time.Millisecond, func() { calls.Add(1) })
+	require.ErrorIs(t, d.Trigger(), ErrClosed)
+
+	tm.Start()
+	defer tm.Stop()
+
+	t.Run("trailing", func(t *testing.T) {
+		calls.Store(0)
+		for i := 0; i < 5; i++ {
+			require.NoError(t, d.Trigger())
+			time.Sleep(10 * time.Millisecond)
+		}
+		require.Zero(t, calls.Load())
+		time.Sleep(60 * time.Millisecond)
+		require.Equal(t, int64(1), calls.Load())
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		calls.Store(0)
+		require.NoError(t, d.Trigger())
+		d.Cancel()
+		time.Sleep(60 * time.Millisecond)
+		require.Zero(t, calls.Load())
+	})
+
+	t.Run("leading", func(t *testing.T) {
+		var calls atomic.Int64
+		d := NewDebouncer(tm, 30*time.Millisecond, func() { calls.Add(1) }, WithLeading(true), WithTrailing(false))
+		for i := 0; i < 5; i++ {
+			require.NoError(t, d.Trigger())
+			time.Sleep(5 * time.Millisecond)
+		}
+		require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+		time.Sleep(60 * time.Millisecond)
+		require.Equal(t, int64(1), calls.Load())
+
+		// a new burst after quiet.
+		require.NoError(t, d.Trigger())
+		require.Eventually(t, func() bool { return calls.Load() == 2 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("leading and trailing", func(t *testing.T) {
+		var calls atomic.Int64
+		d := NewDebouncer(tm, 30*time.Millisecond, func() { calls.Add(1) }, WithLeading(true))
+		// single trigger, only leading call.
+		require.NoError(t, d.Trigger())
+		time.Sleep(60 * time.Millisecond)
+		require.Equal(t, int64(1), calls.Load())
+
+		// more triggers after leading call.
+		calls.Store(0)
+		require.NoError(t, d.Trigger())
+		require.NoError(t, d.Trigger())
+		time.Sleep(60 * time.Millisecond)
+		require.Equal(t, int64(2), calls.Load())
+	})
+
+	t.Run("max wait", func(t *testing.T) {
+		var calls atomic.Int64
+		d := NewDebouncer(tm, 30*time.Millisecond, func() { calls.Add(1) }, WithMaxWait(50*time.Millisecond))
+		for i := 0; i < 20; i++ { // 200ms burst
+			require.NoError(t, d.Trigger())
+			time.Sleep(10 * time.Millisecond)
+		}
+		require.GreaterOrEqual(t, calls.Load(), int64(2))
+		require.LessOrEqual(t, calls.Load(), int64(5))
+	})
+}
+
+func Test_KeyedDebouncer(t *testing.T) {
+	tm := NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	var mu sync.Mutex
+	calls := map[int]int{}
+	d := NewKeyedDebouncer(tm, 30*time.Millisecond, func(key int) {
+		mu.Lock()
+		calls[key]++
+		mu.Unlock()
+	})
+	for i := 0; i < 5; i++ {
+		for key := 0; key < 100; key++ {
+			require.NoError(t, d.Trigger(key))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	require.Equal(t, 100, d.Len())
+	d.Cancel(0)
+	require.Eventually(t, func() bool { return d.Len() == 0 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, calls, 99)
+	for key, n := range calls {
+		require.NotZero(t, key)
+		require.Equal(t, 1, n)
+	}
+}
+
+func Test_Throttler(t *testing.T) {
+	tm := NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	var calls atomic.Int64
+	th := NewThrottler(tm, 50*time.Millisecond, func() { calls.Add(1) })
+	start := time.Now()
+	for time.Since(start) < 240*time.Millisecond {
+		require.NoError(t, th.Trigger())
+		time.Sleep(2 * time.Millisecond)
+	}
+	// leading call, then at most one call per interval.
+	require.GreaterOrEqual(t, calls.Load(), int64(4))
+	require.LessOrEqual(t, calls.Load(), int64(6))
+
+	th.Cancel()
+	n := calls.Load()
+	time.Sleep(120 * time.Millisecond)
+	require.Equal(t, n, calls.Load())
+}
+
+func Test_KeyedThrottler(t *testing.T) {
+	tm := NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	var calls atomic.Int64
+	th := NewKeyedThrottler(tm, 50*time.Millisecond, func(string) { calls.Add(1) })
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(t, th.Trigger(key))
+		require.NoError(t, th.Trigger(key))
+	}
+	require.Equal(t, 3, th.Len())
+	th.Cancel("c")
+	require.Equal(t, 2, th.Len())
+	// leading calls of a, b, c, trailing calls of a, b.
+	require.Eventually(t, func() bool { return calls.Load() == 5 }, time.Second, time.Millisecond)
+	// the quiet window ends.
+	require.Eventually(t, func() bool { return th.Len() == 0 }, time.Second, time.Millisecond)
+}
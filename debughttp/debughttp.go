@@ -0,0 +1,301 @@
+// Package debughttp provides an http.Handler to inspect and control a timer.Timer.
+//
+// The handler serves the following endpoints, relative to where it is mounted:
+//
+//	GET  /                    HTML overview of the timer.
+//	GET  /stats               JSON config and counters.
+//	GET  /tasks?limit=N       JSON upcoming tasks ordered by expiry, default limit 100, 0 means unlimited.
+//	GET  /levels              JSON per-wheel-level occupancy.
+//	POST /tasks/{key}/cancel  cancel the tracked task.
+//	POST /tasks/{key}/fire    fire the tracked task immediately, through the timer.
+//
+// Mount it under a prefix with http.StripPrefix, for example:
+//
+//	http.Handle("/debug/timer/", http.StripPrefix("/debug/timer", debughttp.New(timer.DefaultTimer())))
+package debughttp
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/thinkgos/timer"
+)
+
+// DefaultTaskLimit default limit of the upcoming tasks.
+const DefaultTaskLimit = 100
+
+// Stats the config and counters of the timer.
+type Stats struct {
+	TickMs       int64     `json:"tickMs"`
+	WheelSize    int       `json:"wheelSize"`
+	Started      bool      `json:"started"`
+	TaskCounter  int64     `json:"taskCounter"`
+	Cascades     int64     `json:"cascades"`
+	Wakeups      int64     `json:"wakeups"`
+	Tracked      int       `json:"tracked"`
+	Levels       int       `json:"levels"`
+	NextExpiry   int64     `json:"nextExpiry"` // -1 indicate no task pending.
+	NextExpiryAt time.Time `json:"nextExpiryAt"`
+}
+
+// TaskInfo an upcoming task.
+type TaskInfo struct {
+	Key      string    `json:"key,omitempty"` // empty if the task is not tracked.
+	Expiry   int64     `json:"expiry"`
+	ExpiryAt time.Time `json:"expiryAt"`
+	Delay    string    `json:"delay"`
+}
+
+// LevelInfo the occupancy of a wheel level.
+type LevelInfo struct {
+	Level       int         `json:"level"`
+	TickMs      int64       `json:"tickMs"`
+	Interval    int64       `json:"interval"`
+	CurrentTime int64       `json:"currentTime"`
+	Occupied    int         `json:"occupied"` // the number of non-empty spokes.
+	Tasks       int         `json:"tasks"`
+	Spokes      []SpokeInfo `json:"spokes"`
+}
+
+// SpokeInfo a non-empty spoke.
+type SpokeInfo struct {
+	Index      int   `json:"index"`
+	Expiration int64 `json:"expiration"`
+	Tasks      int   `json:"tasks"`
+}
+
+// Handler an http.Handler serves views of a timer.Timer.
+type Handler struct {
+	timer *timer.Timer
+	mux   *http.ServeMux
+	mu    sync.RWMutex           // protects following fields.
+	tasks map[string]*timer.Task // key -> task
+	keys  map[*timer.Task]string // task -> key
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// New new handler for the timer.
+func New(t *timer.Timer) *Handler {
+	h := &Handler{
+		timer: t,
+		mux:   http.NewServeMux(),
+		tasks: make(map[string]*timer.Task),
+		keys:  make(map[*timer.Task]string),
+	}
+	h.mux.HandleFunc("GET /{$}", h.serveIndex)
+	h.mux.HandleFunc("GET /stats", h.serveStats)
+	h.mux.HandleFunc("GET /tasks", h.serveTasks)
+	h.mux.HandleFunc("GET /levels", h.serveLevels)
+	h.mux.HandleFunc("POST /tasks/{key}/cancel", h.serveCancel)
+	h.mux.HandleFunc("POST /tasks/{key}/fire", h.serveFire)
+	return h
+}
+
+// Track tracks the task with key, so it can be cancelled or fired by key.
+// if the key already tracked, the previous task will be replaced.
+// NOTE: the task is tracked until Untrack, whether it's expired or not.
+func (h *Handler) Track(key string, task *timer.Task) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if old, ok := h.tasks[key]; ok {
+		delete(h.keys, old)
+	}
+	h.tasks[key] = task
+	h.keys[task] = key
+}
+
+// Untrack stops tracking the task with key.
+func (h *Handler) Untrack(key string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if task, ok := h.tasks[key]; ok {
+		delete(h.tasks, key)
+		delete(h.keys, task)
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// Stats return the config and counters of the timer.
+func (h *Handler) Stats() Stats {
+	h.mu.RLock()
+	tracked := len(h.tasks)
+	h.mu.RUnlock()
+	next := h.timer.NextExpiry()
+	s := Stats{
+		TickMs:      h.timer.TickMs(),
+		WheelSize:   h.timer.WheelSize(),
+		Started:     h.timer.Started(),
+		TaskCounter: h.timer.TaskCounter(),
+		Cascades:    h.timer.CascadeCounter(),
+		Wakeups:     h.timer.WakeupCounter(),
+		Tracked:     tracked,
+		Levels:      len(h.timer.Levels()),
+		NextExpiry:  next,
+	}
+	if next >= 0 {
+		s.NextExpiryAt = time.UnixMilli(next)
+	}
+	return s
+}
+
+// Tasks return at most limit upcoming tasks ordered by expiry, limit <= 0 means unlimited.
+func (h *Handler) Tasks(limit int) []TaskInfo {
+	now := time.Now()
+	tasks := make([]TaskInfo, 0, 16)
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for task := range h.timer.PendingByExpiry() {
+		if limit > 0 && len(tasks) >= limit {
+			break
+		}
+		expiry := task.Expiry()
+		if expiry < 0 { // expired or cancelled after the snapshot.
+			continue
+		}
+		expiryAt := time.UnixMilli(expiry)
+		tasks = append(tasks, TaskInfo{
+			Key:      h.keys[task],
+			Expiry:   expiry,
+			ExpiryAt: expiryAt,
+			Delay:    expiryAt.Sub(now).Truncate(time.Millisecond).String(),
+		})
+	}
+	return tasks
+}
+
+// Levels return the occupancy of each wheel level.
+func (h *Handler) Levels() []LevelInfo {
+	levels := h.timer.Levels()
+	infos := make([]LevelInfo, 0, len(levels))
+	for _, wl := range levels {
+		info := LevelInfo{
+			Level:       wl.Level,
+			TickMs:      wl.TickMs,
+			Interval:    wl.Interval,
+			CurrentTime: wl.CurrentTime,
+			Occupied:    len(wl.Spokes),
+			Spokes:      make([]SpokeInfo, 0, len(wl.Spokes)),
+		}
+		for _, sp := range wl.Spokes {
+			info.Tasks += sp.Tasks
+			info.Spokes = append(info.Spokes, SpokeInfo{
+				Index:      sp.Index,
+				Expiration: sp.Expiration,
+				Tasks:      sp.Tasks,
+			})
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+func (h *Handler) serveStats(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, h.Stats())
+}
+
+func (h *Handler) serveTasks(w http.ResponseWriter, r *http.Request) {
+	limit := DefaultTaskLimit
+	if s := r.URL.Query().Get("limit"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil || v < 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		limit = v
+	}
+	writeJSON(w, http.StatusOK, h.Tasks(limit))
+}
+
+func (h *Handler) serveLevels(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, h.Levels())
+}
+
+func (h *Handler) serveCancel(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+	task, ok := h.lookup(key)
+	if !ok {
+		writeError(w, http.StatusNotFound, "task not found")
+		return
+	}
+	task.Cancel()
+	writeJSON(w, http.StatusOK, map[string]any{"key": key, "canceled": true})
+}
+
+func (h *Handler) serveFire(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+	task, ok := h.lookup(key)
+	if !ok {
+		writeError(w, http.StatusNotFound, "task not found")
+		return
+	}
+	if !h.timer.Fire(task) {
+		writeError(w, http.StatusConflict, "task not activated")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"key": key, "fired": true})
+}
+
+func (h *Handler) serveIndex(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = indexTemplate.Execute(w, map[string]any{
+		"Stats":  h.Stats(),
+		"Levels": h.Levels(),
+		"Tasks":  h.Tasks(DefaultTaskLimit),
+	})
+}
+
+func (h *Handler) lookup(key string) (*timer.Task, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	task, ok := h.tasks[key]
+	return task, ok
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	writeJSON(w, code, map[string]string{"error": msg})
+}
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head><title>timer</title></head>
+<body>
+<h1>timer</h1>
+<h2>Stats</h2>
+<table>
+<tr><td>tickMs</td><td>{{.Stats.TickMs}}</td></tr>
+<tr><td>wheelSize</td><td>{{.Stats.WheelSize}}</td></tr>
+<tr><td>started</td><td>{{.Stats.Started}}</td></tr>
+<tr><td>taskCounter</td><td>{{.Stats.TaskCounter}}</td></tr>
+<tr><td>cascades</td><td>{{.Stats.Cascades}}</td></tr>
+<tr><td>wakeups</td><td>{{.Stats.Wakeups}}</td></tr>
+<tr><td>tracked</td><td>{{.Stats.Tracked}}</td></tr>
+<tr><td>nextExpiry</td><td>{{if ge .Stats.NextExpiry 0}}{{.Stats.NextExpiryAt}}{{else}}none{{end}}</td></tr>
+</table>
+<h2>Levels</h2>
+<table>
+<tr><th>level</th><th>tickMs</th><th>interval</th><th>currentTime</th><th>occupied</th><th>tasks</th></tr>
+{{range .Levels}}<tr><td>{{.Level}}</td><td>{{.TickMs}}</td><td>{{.Interval}}</td><td>{{.CurrentTime}}</td><td>{{.Occupied}}</td><td>{{.Tasks}}</td></tr>
+{{end}}</table>
+<h2>Upcoming tasks</h2>
+<table>
+<tr><th>key</th><th>expiryAt</th><th>delay</th></tr>
+{{range .Tasks}}<tr><td>{{.Key}}</td><td>{{.ExpiryAt}}</td><td>{{.Delay}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
@@ -0,0 +1,131 @@
+package debughttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thinkgos/timer"
+)
+
+func doRequest(t *testing.T, h http.Handler, method, target string, v any) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+	if v != nil {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), v), w.Body.String())
+	}
+	return w
+}
+
+func Test_Handler(t *testing.T) {
+	tm := timer.NewTimer(timer.WithTickMs(2), timer.WithWheelSize(16))
+	tm.Start()
+	defer tm.Stop()
+	h := New(tm)
+
+	fired := &atomic.Int64{}
+	task1 := timer.NewTaskFunc(time.Minute, func() { fired.Add(1) })
+	task2 := timer.NewTaskFunc(time.Hour, func() { fired.Add(10) })
+	task3 := timer.NewTask(time.Second)
+	for _, task := range []*timer.Task{task1, task2, task3} {
+		require.NoError(t, tm.AddTask(task))
+	}
+	h.Track("task1", task1)
+	h.Track("task2", task2)
+	h.Track("removed", task3)
+	h.Untrack("removed")
+
+	t.Run("stats", func(t *testing.T) {
+		var stats Stats
+		w := doRequest(t, h, http.MethodGet, "/stats", &stats)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		require.Equal(t, int64(2), stats.TickMs)
+		require.Equal(t, 16, stats.WheelSize)
+		require.True(t, stats.Started)
+		require.Equal(t, int64(3), stats.TaskCounter)
+		require.Equal(t, 2, stats.Tracked)
+		require.Greater(t, stats.Levels, 1)
+		require.Greater(t, stats.NextExpiry, int64(0))
+	})
+
+	t.Run("tasks", func(t *testing.T) {
+		var tasks []TaskInfo
+		w := doRequest(t, h, http.MethodGet, "/tasks", &tasks)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, tasks, 3)
+		require.Equal(t, "", tasks[0].Key)
+		require.Equal(t, "task1", tasks[1].Key)
+		require.Equal(t, "task2", tasks[2].Key)
+		require.Equal(t, task1.Expiry(), tasks[1].Expiry)
+		require.True(t, task1.ExpiryAt().Equal(tasks[1].ExpiryAt))
+
+		w = doRequest(t, h, http.MethodGet, "/tasks?limit=1", &tasks)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Len(t, tasks, 1)
+
+		w = doRequest(t, h, http.MethodGet, "/tasks?limit=x", nil)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("levels", func(t *testing.T) {
+		var levels []LevelInfo
+		w := doRequest(t, h, http.MethodGet, "/levels", &levels)
+		require.Equal(t, http.StatusOK, w.Code)
+		total := 0
+		for i, level := range levels {
+			require.Equal(t, i, level.Level)
+			require.Equal(t, len(level.Spokes), level.Occupied)
+			total += level.Tasks
+		}
+		require.Equal(t, 3, total)
+	})
+
+	t.Run("index", func(t *testing.T) {
+		w := doRequest(t, h, http.MethodGet, "/", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), "text/html"))
+		require.Contains(t, w.Body.String(), "task1")
+		require.Contains(t, w.Body.String(), "task2")
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		w := doRequest(t, h, http.MethodPost, "/tasks/task2/cancel", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.False(t, task2.Activated())
+
+		w = doRequest(t, h, http.MethodPost, "/tasks/unknown/cancel", nil)
+		require.Equal(t, http.StatusNotFound, w.Code)
+
+		w = doRequest(t, h, http.MethodGet, "/tasks/task2/cancel", nil)
+		require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+
+	t.Run("fire", func(t *testing.T) {
+		w := doRequest(t, h, http.MethodPost, "/tasks/task1/fire", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.False(t, task1.Activated())
+		require.Eventually(t, func() bool { return fired.Load() == 1 }, time.Second, time.Millisecond)
+
+		w = doRequest(t, h, http.MethodPost, "/tasks/task1/fire", nil)
+		require.Equal(t, http.StatusConflict, w.Code)
+		w = doRequest(t, h, http.MethodPost, "/tasks/unknown/fire", nil)
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.Equal(t, int64(1), fired.Load())
+	})
+
+	t.Run("mount with prefix", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.Handle("/debug/timer/", http.StripPrefix("/debug/timer", h))
+		var stats Stats
+		w := doRequest(t, mux, http.MethodGet, "/debug/timer/stats", &stats)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, int64(1), stats.TaskCounter)
+	})
+}
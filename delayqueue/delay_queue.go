@@ -108,3 +108,17 @@ func (dq *DelayQueue[T]) Poll() (val T, exist bool) {
 		return phantom, false
 	}
 }
+
+// Peek retrieves, but does not remove, the head of this queue whether it is expired or not.
+func (dq *DelayQueue[T]) Peek() (val T, exist bool) {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	return dq.priorityQueue.Peek()
+}
+
+// Len returns the number of elements in this queue.
+func (dq *DelayQueue[T]) Len() int {
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	return dq.priorityQueue.Len()
+}
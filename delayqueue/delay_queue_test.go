@@ -157,3 +157,21 @@ func Test_DelayQueue_Poll(t *testing.T) {
 	require.False(t, exist)
 	assert.Nil(t, v2)
 }
+
+func Test_DelayQueue_Peek(t *testing.T) {
+	dq := NewDelayQueue(compareDelay)
+	require.Zero(t, dq.Len())
+	v, exist := dq.Peek()
+	require.False(t, exist)
+	assert.Nil(t, v)
+
+	d1 := &delay{"d1", time.Now().UnixMilli() + 200}
+	d2 := &delay{"d2", time.Now().UnixMilli() + 100}
+	dq.Add(d1)
+	dq.Add(d2)
+
+	v, exist = dq.Peek()
+	require.True(t, exist)
+	assert.Equal(t, "d2", v.name)
+	require.Equal(t, 2, dq.Len())
+}
@@ -0,0 +1,191 @@
+package timer
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/thinkgos/timer/comparator"
+	"github.com/thinkgos/timer/queue"
+)
+
+// BusyPolicy the policy of the expired task when the concurrent jobs reach the limit.
+type BusyPolicy int
+
+const (
+	BusyPolicy_Queue BusyPolicy = iota // queue the job, run it once a running job finished.
+	BusyPolicy_Skip                    // skip the job, except the task WithNoSkip, which is queued.
+)
+
+// String implements fmt.Stringer.
+func (p BusyPolicy) String() string {
+	switch p {
+	case BusyPolicy_Queue:
+		return "queue"
+	case BusyPolicy_Skip:
+		return "skip"
+	default:
+		return "unknown"
+	}
+}
+
+// WithMaxConcurrentJobs limit the number of jobs running at once, 0 means no limit.
+// the jobs over the limit are queued or skipped according to the policy,
+// so a flood of expirations can't overwhelm the downstream services.
+func WithMaxConcurrentJobs(n int, policy BusyPolicy) Option {
+	return func(t *Timer) {
+		t.jobs.max = n
+		t.jobs.policy = policy
+	}
+}
+
+// jobLimiter limits the number of jobs running at once.
+type jobLimiter struct {
+	max     int          // the maximum number of jobs running at once, 0 means no limit.
+	policy  BusyPolicy   // the policy when the limit is reached.
+	skipped atomic.Int64 // the total number of jobs skipped.
+	mu      sync.Mutex   // protects following fields.
+	running int          // the number of jobs running.
+	queue   jobQueue     // the queued jobs.
+}
+
+// queuedJob a job waiting for running.
+type queuedJob struct {
+	job      Job
+	priority int
+	seq      uint64
+}
+
+// compareQueuedJob higher priority first, then first in first out.
+var compareQueuedJob = comparator.Then(
+	comparator.Reverse(comparator.By(func(j *queuedJob) int { return j.priority })),
+	comparator.By(func(j *queuedJob) uint64 { return j.seq }),
+)
+
+// compareExpiredEntry higher priority first, then expiry order.
+var compareExpiredEntry = comparator.Chain(
+	comparator.Reverse(comparator.By(func(te *taskEntry) int { return te.task.priority })),
+	comparator.By(func(te *taskEntry) int64 { return te.expirationMs }),
+	comparator.By(func(te *taskEntry) uint64 { return te.seq }),
+)
+
+// jobQueue the jobs waiting for running, higher priority first, then first in first out.
+type jobQueue struct {
+	pq  *queue.PriorityQueue[*queuedJob] // created lazily.
+	seq uint64
+}
+
+func (q *jobQueue) len() int {
+	if q.pq == nil {
+		return 0
+	}
+	return q.pq.Len()
+}
+
+func (q *jobQueue) push(job Job, priority int) {
+	if q.pq == nil {
+		q.pq = queue.NewPriorityQueueWith(compareQueuedJob)
+	}
+	q.seq++
+	q.pq.Push(&queuedJob{job: job, priority: priority, seq: q.seq})
+}
+
+func (q *jobQueue) pop() (Job, bool) {
+	if q.pq == nil {
+		return nil, false
+	}
+	j, ok := q.pq.Pop()
+	if !ok {
+		return nil, false
+	}
+	return j.job, true
+}
+
+func (q *jobQueue) clear() {
+	if q.pq != nil {
+		q.pq.Clear()
+	}
+}
+
+// RunningJobs return the number of jobs running, only counted if WithMaxConcurrentJobs is set.
+func (t *Timer) RunningJobs() int {
+	t.jobs.mu.Lock()
+	defer t.jobs.mu.Unlock()
+	return t.jobs.running
+}
+
+// QueuedJobs return the number of jobs waiting for running, only with BusyPolicy_Queue.
+func (t *Timer) QueuedJobs() int {
+	t.jobs.mu.Lock()
+	defer t.jobs.mu.Unlock()
+	return t.jobs.queue.len()
+}
+
+// SkippedJobs return the total number of jobs skipped, only with BusyPolicy_Skip.
+func (t *Timer) SkippedJobs() int64 { return t.jobs.skipped.Load() }
+
+// dispatch run the job of the expired task.
+func (t *Timer) dispatch(job Job, task *Task) {
+	if g := task.group.Load(); g != nil {
+		g.dispatch(job, task)
+		return
+	}
+	t.execute(job, task)
+}
+
+// dispatchExpired dispatch the task entries expired in the same advance batch, in priority order.
+// NOTE: only called by the advance goroutine.
+func (t *Timer) dispatchExpired() {
+	if len(t.expired) > 1 {
+		slices.SortStableFunc(t.expired, compareExpiredEntry)
+	}
+	for _, te := range t.expired {
+		// cancelled or rescheduled after expired.
+		if !te.cancelled() {
+			t.fire(te)
+		}
+		te.finish()
+	}
+	clear(t.expired)
+	t.expired = t.expired[:0]
+}
+
+// submit run the job through the `GoPool`, under the limit of the concurrent jobs.
+// It returns false if the job is skipped, the job not skippable is queued instead.
+func (t *Timer) submit(job Job, priority int, skippable bool) bool {
+	if t.jobs.max <= 0 {
+		t.goPool.Go(job.Run)
+		return true
+	}
+	t.jobs.mu.Lock()
+	switch {
+	case t.jobs.running < t.jobs.max:
+		t.jobs.running++
+		t.jobs.mu.Unlock()
+		t.goPool.Go(func() { t.runJobs(job) })
+	case t.jobs.policy == BusyPolicy_Skip && skippable:
+		t.jobs.mu.Unlock()
+		t.jobs.skipped.Add(1)
+		return false
+	default:
+		t.jobs.queue.push(job, priority)
+		t.jobs.mu.Unlock()
+	}
+	return true
+}
+
+// runJobs run the job, then the queued jobs until the queue is empty.
+func (t *Timer) runJobs(job Job) {
+	for {
+		job.Run()
+		t.jobs.mu.Lock()
+		next, ok := t.jobs.queue.pop()
+		if !ok {
+			t.jobs.running--
+			t.jobs.mu.Unlock()
+			return
+		}
+		job = next
+		t.jobs.mu.Unlock()
+	}
+}
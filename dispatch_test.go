@@ -0,0 +1,183 @@
+package timer
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BusyPolicy_String(t *testing.T) {
+	require.Equal(t, "queue", BusyPolicy_Queue.String())
+	require.Equal(t, "skip", BusyPolicy_Skip.String())
+	require.Equal(t, "unknown", BusyPolicy(100).String())
+}
+
+func Test_Timer_MaxConcurrentJobs(t *testing.T) {
+	const jobs = 20
+
+	runJobs := func(tm *Timer) (maxRunning, ran int64) {
+		var running, peak, done atomic.Int64
+		for i := 0; i < jobs; i++ {
+			_, err := tm.AfterFunc(10*time.Millisecond, func() {
+				n := running.Add(1)
+				for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+				}
+				time.Sleep(10 * time.Millisecond)
+				running.Add(-1)
+				done.Add(1)
+			})
+			require.NoError(t, err)
+		}
+		require.Eventually(t, func() bool { return done.Load()+tm.SkippedJobs() == jobs }, 2*time.Second, time.Millisecond)
+		require.Zero(t, tm.QueuedJobs())
+		require.Eventually(t, func() bool { return tm.RunningJobs() == 0 }, time.Second, time.Millisecond)
+		return peak.Load(), done.Load()
+	}
+
+	t.Run("queue", func(t *testing.T) {
+		tm := NewTimer(WithMaxConcurrentJobs(3, BusyPolicy_Queue))
+		tm.Start()
+		defer tm.Stop()
+
+		peak, ran := runJobs(tm)
+		require.LessOrEqual(t, peak, int64(3))
+		require.Equal(t, int64(jobs), ran)
+		require.Zero(t, tm.SkippedJobs())
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		tm := NewTimer(WithMaxConcurrentJobs(3, BusyPolicy_Skip))
+		tm.Start()
+		defer tm.Stop()
+
+		peak, ran := runJobs(tm)
+		require.LessOrEqual(t, peak, int64(3))
+		require.Less(t, ran, int64(jobs))
+		require.Equal(t, int64(jobs), ran+tm.SkippedJobs())
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		tm := NewTimer()
+		tm.Start()
+		defer tm.Stop()
+
+		_, ran := runJobs(tm)
+		require.Equal(t, int64(jobs), ran)
+	})
+}
+
+func Test_Timer_MaxConcurrentJobs_NoSkip(t *testing.T) {
+	tm := NewTimer(WithMaxConcurrentJobs(1, BusyPolicy_Skip))
+	tm.Start()
+	defer tm.Stop()
+
+	release := make(chan struct{})
+	defer close(release)
+	_, err := tm.AfterFunc(0, func() { <-release })
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return tm.RunningJobs() == 1 }, time.Second, time.Millisecond)
+
+	// the ticker re-adds itself in the job, it keeps ticking once the slot is free.
+	ticker := tm.NewStdTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	skipped := NewTaskFunc(10*time.Millisecond, func() { t.Error("skipped task should not run") })
+	require.NoError(t, tm.AddTask(skipped))
+	require.Eventually(t, func() bool { return tm.SkippedJobs() == 1 && tm.QueuedJobs() == 1 }, time.Second, time.Millisecond)
+	release <- struct{}{}
+	for range 3 {
+		select {
+		case <-ticker.C:
+		case <-time.After(time.Second):
+			t.Fatal("the ticker should keep ticking")
+		}
+	}
+	require.Equal(t, int64(1), tm.SkippedJobs())
+}
+
+func Test_JobQueue(t *testing.T) {
+	var q jobQueue
+	_, ok := q.pop()
+	require.False(t, ok)
+	require.Zero(t, q.len())
+	q.clear()
+
+	var got []int
+	for i, p := range []int{0, 2, 1, 2, 0} {
+		q.push(JobFunc(func() { got = append(got, i) }), p)
+	}
+	require.Equal(t, 5, q.len())
+	for job, ok := q.pop(); ok; job, ok = q.pop() {
+		job.Run()
+	}
+	// higher priority first, then first in first out.
+	require.Equal(t, []int{1, 3, 2, 0, 4}, got)
+
+	q.push(JobFunc(func() {}), 0)
+	q.clear()
+	require.Zero(t, q.len())
+}
+
+func Test_Timer_Priority(t *testing.T) {
+	tm := NewTimer(WithMaxConcurrentJobs(1, BusyPolicy_Queue))
+	tm.Start()
+	defer tm.Stop()
+
+	priorities := []int{0, 5, -1, 10, 5, 3}
+	var mu sync.Mutex
+	var got []int
+	at := time.Now().Add(20 * time.Millisecond)
+	for i, p := range priorities {
+		task := NewTaskAt(at).WithPriority(p).WithJobFunc(func() {
+			mu.Lock()
+			got = append(got, i)
+			mu.Unlock()
+		})
+		require.Equal(t, p, task.Priority())
+		require.NoError(t, tm.AddTask(task))
+	}
+	// a cancelled task in the batch is not dispatched.
+	cancelled := NewTaskAt(at).WithPriority(100).WithJobFunc(func() { t.Error("cancelled task should not run") })
+	require.NoError(t, tm.AddTask(cancelled))
+	cancelled.Cancel()
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(got) == len(priorities)
+	}, time.Second, time.Millisecond)
+	require.Equal(t, []int{3, 1, 4, 5, 0, 2}, got)
+}
+
+// syncPool runs the function in the caller's goroutine, in the order handed to the `GoPool`.
+type syncPool struct{}
+
+func (syncPool) Go(f func()) { f() }
+
+func Test_Timer_Priority_NoLimit(t *testing.T) {
+	// without the limit of concurrent jobs, the jobs are handed to the `GoPool` in priority order.
+	tm := NewTimer(WithGoPool(syncPool{}))
+	tm.Start()
+	defer tm.Stop()
+
+	priorities := []int{0, 5, -1, 10, 5, 3}
+	var mu sync.Mutex
+	var got []int
+	at := time.Now().Add(20 * time.Millisecond)
+	for i, p := range priorities {
+		task := NewTaskAt(at).WithPriority(p).WithJobFunc(func() {
+			mu.Lock()
+			got = append(got, i)
+			mu.Unlock()
+		})
+		require.NoError(t, tm.AddTask(task))
+	}
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(got) == len(priorities)
+	}, time.Second, time.Millisecond)
+	require.Equal(t, []int{3, 1, 4, 5, 0, 2}, got)
+}
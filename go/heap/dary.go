@@ -0,0 +1,115 @@
+package heap
+
+// DAry provides d-ary heap operations for any type that implements heap.Interface.
+// A d-ary heap is a generalization of the binary heap in which each node has d children,
+// the children of the node at index i are at indices d*i+1 through d*i+d.
+//
+// A d-ary heap has a shallower tree than the binary heap, Push is cheaper (O(log_d n))
+// and the children of a node are adjacent in memory, which gives better cache behavior
+// for large heaps, at the cost of more comparisons per level in Pop.
+// A 4-ary heap is usually a good choice.
+//
+// A DAry with d = 2 is equivalent to the binary heap provided by [Init], [Push], [Pop], [Remove] and [Fix].
+type DAry[T any] struct {
+	d int
+}
+
+// NewDAry returns a d-ary heap operations, d must be greater than or equal to 2.
+func NewDAry[T any](d int) DAry[T] {
+	if d < 2 {
+		panic("heap: d-ary heap arity must be greater than or equal to 2")
+	}
+	return DAry[T]{d: d}
+}
+
+// Arity return the number of children of each node.
+func (da DAry[T]) Arity() int { return da.d }
+
+// Init establishes the heap invariants required by the other routines in this package.
+// Init is idempotent with respect to the heap invariants
+// and may be called whenever the heap invariants may have been invalidated.
+// The complexity is O(n) where n = h.Len().
+func (da DAry[T]) Init(h Interface[T]) {
+	// heapify
+	n := h.Len()
+	for i := (n - 2) / da.d; i >= 0; i-- {
+		da.down(h, i, n)
+	}
+}
+
+// Push pushes the element x onto the heap.
+// The complexity is O(log_d n) where n = h.Len().
+func (da DAry[T]) Push(h Interface[T], x T) {
+	h.Push(x)
+	da.up(h, h.Len()-1)
+}
+
+// Pop removes and returns the minimum element (according to Less) from the heap.
+// The complexity is O(d*log_d n) where n = h.Len().
+// Pop is equivalent to Remove(h, 0).
+func (da DAry[T]) Pop(h Interface[T]) T {
+	n := h.Len() - 1
+	h.Swap(0, n)
+	da.down(h, 0, n)
+	return h.Pop()
+}
+
+// Remove removes and returns the element at index i from the heap.
+// The complexity is O(d*log_d n) where n = h.Len().
+func (da DAry[T]) Remove(h Interface[T], i int) T {
+	n := h.Len() - 1
+	if n != i {
+		h.Swap(i, n)
+		if !da.down(h, i, n) {
+			da.up(h, i)
+		}
+	}
+	return h.Pop()
+}
+
+// Fix re-establishes the heap ordering after the element at index i has changed its value.
+// Changing the value of the element at index i and then calling Fix is equivalent to,
+// but less expensive than, calling Remove(h, i) followed by a Push of the new value.
+// The complexity is O(d*log_d n) where n = h.Len().
+func (da DAry[T]) Fix(h Interface[T], i int) {
+	if !da.down(h, i, h.Len()) {
+		da.up(h, i)
+	}
+}
+
+func (da DAry[T]) up(h Interface[T], j int) {
+	for j > 0 {
+		i := (j - 1) / da.d // parent
+		if !h.Less(j, i) {
+			break
+		}
+		h.Swap(i, j)
+		j = i
+	}
+}
+
+func (da DAry[T]) down(h Interface[T], i0, n int) bool {
+	i := i0
+	for {
+		j1 := da.d*i + 1
+		if j1 >= n || j1 < 0 { // j1 < 0 after int overflow
+			break
+		}
+		last := j1 + da.d
+		if last > n || last < 0 { // last < 0 after int overflow
+			last = n
+		}
+		j := j1 // first child
+		for k := j1 + 1; k < last; k++ {
+			if h.Less(k, j) {
+				j = k // the minimum child
+			}
+		}
+		if !h.Less(j, i) {
+			break
+		}
+		h.Swap(i, j)
+		i = j
+	}
+	return i > i0
+}
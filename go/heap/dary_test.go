@@ -0,0 +1,133 @@
+package heap
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func (h myHeap) verifyDAry(t *testing.T, d, i int) {
+	t.Helper()
+	n := h.Len()
+	for j := d*i + 1; j <= d*i+d && j < n; j++ {
+		if h.Less(j, i) {
+			t.Errorf("heap invariant invalidated [%d] = %d > [%d] = %d", i, h[i], j, h[j])
+			return
+		}
+		h.verifyDAry(t, d, j)
+	}
+}
+
+func TestDAry_Invalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewDAry(1) should panic")
+		}
+	}()
+	_ = NewDAry[int](1)
+}
+
+func TestDAry_Init(t *testing.T) {
+	for _, d := range []int{2, 3, 4, 8} {
+		da := NewDAry[int](d)
+		if da.Arity() != d {
+			t.Errorf("Arity() got %d; want %d", da.Arity(), d)
+		}
+
+		h := new(myHeap)
+		for i := 20; i > 0; i-- {
+			h.Push(i) // all elements are different
+		}
+		da.Init(h)
+		h.verifyDAry(t, d, 0)
+
+		for i := 1; h.Len() > 0; i++ {
+			x := da.Pop(h)
+			h.verifyDAry(t, d, 0)
+			if x != i {
+				t.Errorf("d=%d %d.th pop got %d; want %d", d, i, x, i)
+			}
+		}
+	}
+}
+
+func TestDAry(t *testing.T) {
+	for _, d := range []int{2, 3, 4, 8} {
+		da := NewDAry[int](d)
+		h := new(myHeap)
+		h.verifyDAry(t, d, 0)
+
+		for i := 20; i > 10; i-- {
+			h.Push(i)
+		}
+		da.Init(h)
+		h.verifyDAry(t, d, 0)
+
+		for i := 10; i > 0; i-- {
+			da.Push(h, i)
+			h.verifyDAry(t, d, 0)
+		}
+
+		for i := 1; h.Len() > 0; i++ {
+			x := da.Pop(h)
+			if i < 20 {
+				da.Push(h, 20+i)
+			}
+			h.verifyDAry(t, d, 0)
+			if x != i {
+				t.Errorf("d=%d %d.th pop got %d; want %d", d, i, x, i)
+			}
+		}
+	}
+}
+
+func TestDAry_Remove(t *testing.T) {
+	const N = 30
+
+	da := NewDAry[int](4)
+	h := new(myHeap)
+	for i := 0; i < N; i++ {
+		da.Push(h, i)
+	}
+	h.verifyDAry(t, 4, 0)
+
+	m := make(map[int]bool)
+	for h.Len() > 0 {
+		m[da.Remove(h, (h.Len()-1)/2)] = true
+		h.verifyDAry(t, 4, 0)
+	}
+	if len(m) != N {
+		t.Errorf("len(m) = %d; want %d", len(m), N)
+	}
+	for i := 0; i < len(m); i++ {
+		if !m[i] {
+			t.Errorf("m[%d] doesn't exist", i)
+		}
+	}
+}
+
+func TestDAry_Fix(t *testing.T) {
+	da := NewDAry[int](4)
+	h := new(myHeap)
+	for i := 200; i > 0; i -= 10 {
+		da.Push(h, i)
+	}
+	h.verifyDAry(t, 4, 0)
+
+	if (*h)[0] != 10 {
+		t.Fatalf("Expected head to be 10, was %d", (*h)[0])
+	}
+	(*h)[0] = 210
+	da.Fix(h, 0)
+	h.verifyDAry(t, 4, 0)
+
+	for i := 100; i > 0; i-- {
+		elem := rand.Intn(h.Len())
+		if i&1 == 0 {
+			(*h)[elem] *= 2
+		} else {
+			(*h)[elem] /= 2
+		}
+		da.Fix(h, elem)
+		h.verifyDAry(t, 4, 0)
+	}
+}
@@ -0,0 +1,152 @@
+package heap
+
+// Pairing is a pairing heap, a heap-ordered multiway tree.
+// Unlike the heap operations on [Interface], it is a node-based heap and holds the elements itself.
+//
+// Push and Peek are O(1), Pop is amortized O(log n).
+// A pairing heap is a good choice when elements are pushed much more often than popped.
+//
+// The zero value is not usable, use [NewPairing] to create.
+type Pairing[T any] struct {
+	root *pairingNode[T]
+	size int
+	less func(a, b T) bool
+}
+
+type pairingNode[T any] struct {
+	value   T
+	child   *pairingNode[T] // the first child.
+	sibling *pairingNode[T] // the next sibling.
+}
+
+// NewPairing new pairing heap with less function, the minimum element (according to less) is the root.
+func NewPairing[T any](less func(a, b T) bool) *Pairing[T] {
+	return &Pairing[T]{less: less}
+}
+
+// Len returns the number of elements in the heap.
+func (p *Pairing[T]) Len() int { return p.size }
+
+// Push pushes the element x onto the heap.
+// The complexity is O(1).
+func (p *Pairing[T]) Push(x T) {
+	p.root = p.meld(p.root, &pairingNode[T]{value: x})
+	p.size++
+}
+
+// Peek returns the minimum element (according to less) without removing it.
+// The complexity is O(1).
+// NOTE: Peek panics if the heap is empty.
+func (p *Pairing[T]) Peek() T {
+	return p.root.value
+}
+
+// Pop removes and returns the minimum element (according to less) from the heap.
+// The complexity is amortized O(log n) where n = p.Len().
+// NOTE: Pop panics if the heap is empty.
+func (p *Pairing[T]) Pop() T {
+	root := p.root
+	p.root = p.mergePairs(root.child)
+	p.size--
+	root.child = nil // avoid memory leak
+	return root.value
+}
+
+// Clear removes all the elements from the heap.
+func (p *Pairing[T]) Clear() {
+	p.root = nil
+	p.size = 0
+}
+
+// Clone returns a copy of the heap with the same shape, the elements are shallow copied.
+// The complexity is O(n) where n = p.Len().
+func (p *Pairing[T]) Clone() *Pairing[T] {
+	c := &Pairing[T]{size: p.size, less: p.less}
+	if p.root == nil {
+		return c
+	}
+	c.root = &pairingNode[T]{value: p.root.value}
+	type pair struct{ src, dst *pairingNode[T] }
+	stack := []pair{{p.root, c.root}}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if n.src.child != nil {
+			n.dst.child = &pairingNode[T]{value: n.src.child.value}
+			stack = append(stack, pair{n.src.child, n.dst.child})
+		}
+		if n.src.sibling != nil {
+			n.dst.sibling = &pairingNode[T]{value: n.src.sibling.value}
+			stack = append(stack, pair{n.src.sibling, n.dst.sibling})
+		}
+	}
+	return c
+}
+
+// Range calls f for each element in the heap, in no particular order.
+// If f returns false, Range stops the iteration.
+// NOTE: the heap must not be modified during the iteration.
+func (p *Pairing[T]) Range(f func(T) bool) {
+	if p.root == nil {
+		return
+	}
+	stack := []*pairingNode[T]{p.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !f(n.value) {
+			return
+		}
+		if n.sibling != nil {
+			stack = append(stack, n.sibling)
+		}
+		if n.child != nil {
+			stack = append(stack, n.child)
+		}
+	}
+}
+
+// meld merges two heaps, a and b must be roots without siblings.
+func (p *Pairing[T]) meld(a, b *pairingNode[T]) *pairingNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if p.less(b.value, a.value) {
+		a, b = b, a
+	}
+	b.sibling = a.child
+	a.child = b
+	return a
+}
+
+// mergePairs merges the sibling list with the standard two-pass strategy,
+// it is iterative to avoid deep recursion.
+func (p *Pairing[T]) mergePairs(first *pairingNode[T]) *pairingNode[T] {
+	// first pass: meld pairs from left to right, collected in reverse order.
+	var pairs *pairingNode[T]
+	for n := first; n != nil; {
+		a, b := n, n.sibling
+		if b == nil {
+			a.sibling = pairs
+			pairs = a
+			break
+		}
+		n = b.sibling
+		a.sibling, b.sibling = nil, nil
+		m := p.meld(a, b)
+		m.sibling = pairs
+		pairs = m
+	}
+	// second pass: meld from right to left.
+	var root *pairingNode[T]
+	for pairs != nil {
+		next := pairs.sibling
+		pairs.sibling = nil
+		root = p.meld(root, pairs)
+		pairs = next
+	}
+	return root
+}
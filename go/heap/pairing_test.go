@@ -0,0 +1,171 @@
+package heap
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestPairing(t *testing.T) {
+	p := NewPairing(func(a, b int) bool { return a < b })
+	if p.Len() != 0 {
+		t.Fatalf("Len() got %d; want 0", p.Len())
+	}
+
+	for i := 20; i > 10; i-- {
+		p.Push(i)
+	}
+	for i := 10; i > 0; i-- {
+		p.Push(i)
+		if p.Peek() != i {
+			t.Errorf("Peek() got %d; want %d", p.Peek(), i)
+		}
+	}
+	for i := 1; p.Len() > 0; i++ {
+		x := p.Pop()
+		if i < 20 {
+			p.Push(20 + i)
+		}
+		if x != i {
+			t.Errorf("%d.th pop got %d; want %d", i, x, i)
+		}
+	}
+
+	p.Push(1)
+	p.Clear()
+	if p.Len() != 0 {
+		t.Errorf("Len() got %d; want 0", p.Len())
+	}
+}
+
+func TestPairing_Random(t *testing.T) {
+	const N = 1000
+
+	p := NewPairing(func(a, b int) bool { return a > b }) // max heap
+	want := make([]int, 0, N)
+	for i := 0; i < N; i++ {
+		v := rand.Intn(N / 2) // with duplicates
+		p.Push(v)
+		want = append(want, v)
+	}
+	slices.Sort(want)
+	slices.Reverse(want)
+	for i := 0; p.Len() > 0; i++ {
+		if x := p.Pop(); x != want[i] {
+			t.Fatalf("%d.th pop got %d; want %d", i, x, want[i])
+		}
+	}
+}
+
+func BenchmarkHeaps(b *testing.B) {
+	const n = 10000
+
+	input := make([]int, n)
+	for i := range input {
+		input[i] = rand.Intn(n)
+	}
+	b.Run("binary", func(b *testing.B) {
+		h := make(myHeap, 0, n)
+		for i := 0; i < b.N; i++ {
+			for _, v := range input {
+				Push(&h, v)
+			}
+			for h.Len() > 0 {
+				Pop(&h)
+			}
+		}
+	})
+	b.Run("4-ary", func(b *testing.B) {
+		da := NewDAry[int](4)
+		h := make(myHeap, 0, n)
+		for i := 0; i < b.N; i++ {
+			for _, v := range input {
+				da.Push(&h, v)
+			}
+			for h.Len() > 0 {
+				da.Pop(&h)
+			}
+		}
+	})
+	b.Run("pairing", func(b *testing.B) {
+		p := NewPairing(func(a, b int) bool { return a < b })
+		for i := 0; i < b.N; i++ {
+			for _, v := range input {
+				p.Push(v)
+			}
+			for p.Len() > 0 {
+				p.Pop()
+			}
+		}
+	})
+}
+
+func TestPairing_Range(t *testing.T) {
+	p := NewPairing(func(a, b int) bool { return a < b })
+	p.Range(func(int) bool {
+		t.Fatal("Range on empty heap should not call f")
+		return true
+	})
+	for i := 0; i < 100; i++ {
+		p.Push(rand.Intn(50))
+	}
+	p.Pop()
+	p.Pop()
+
+	got := make([]int, 0, p.Len())
+	p.Range(func(v int) bool {
+		got = append(got, v)
+		return true
+	})
+	want := make([]int, 0, p.Len())
+	for p.Len() > 0 {
+		want = append(want, p.Pop())
+	}
+	slices.Sort(got)
+	if !slices.Equal(got, want) {
+		t.Errorf("Range got %v; want %v", got, want)
+	}
+
+	// stop early
+	p.Push(1)
+	p.Push(2)
+	n := 0
+	p.Range(func(int) bool {
+		n++
+		return false
+	})
+	if n != 1 {
+		t.Errorf("Range should stop after f return false, called %d", n)
+	}
+}
+
+func TestPairing_Clone(t *testing.T) {
+	p := NewPairing(func(a, b int) bool { return a < b })
+	if c := p.Clone(); c.Len() != 0 {
+		t.Fatalf("Clone of empty heap Len() got %d; want 0", c.Len())
+	}
+	for i := 0; i < 100; i++ {
+		p.Push(rand.Intn(50))
+	}
+	p.Pop()
+
+	c := p.Clone()
+	if c.Len() != p.Len() {
+		t.Fatalf("Clone Len() got %d; want %d", c.Len(), p.Len())
+	}
+	c.Push(-1) // not affect the origin
+	if p.Peek() == -1 {
+		t.Fatalf("modify clone should not affect the origin")
+	}
+	if c.Pop() != -1 {
+		t.Fatalf("clone Pop() should be -1")
+	}
+	for p.Len() > 0 {
+		if x, y := p.Pop(), c.Pop(); x != y {
+			t.Fatalf("clone pop got %d; want %d", y, x)
+		}
+	}
+	if c.Len() != 0 {
+		t.Errorf("clone Len() got %d; want 0", c.Len())
+	}
+}
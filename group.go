@@ -0,0 +1,221 @@
+package timer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrGroupFull is returned when the pending tasks of the group reach the limit.
+var ErrGroupFull = errors.New("timer: group is full")
+
+// GroupOption `TaskGroup` custom options.
+type GroupOption func(*TaskGroup)
+
+// WithGroupMaxPending limit the number of pending tasks of the group, 0 means no limit.
+func WithGroupMaxPending(n int) GroupOption {
+	return func(g *TaskGroup) {
+		g.maxPending = n
+	}
+}
+
+// WithGroupMaxConcurrent limit the number of jobs of the group running at once, 0 means no limit.
+// the jobs over the limit are queued, in priority order.
+func WithGroupMaxConcurrent(n int) GroupOption {
+	return func(g *TaskGroup) {
+		g.maxConcurrent = n
+	}
+}
+
+// TaskGroup tracks related tasks, such as per tenant or per request, so that they can be
+// cancelled and waited together. The task is removed from the group automatically when
+// it fires or is cancelled.
+// NOTE: a task belongs to at most one group.
+type TaskGroup struct {
+	name          string
+	timer         *Timer
+	maxPending    int                // the maximum number of pending tasks, 0 means no limit.
+	maxConcurrent int                // the maximum number of jobs running at once, 0 means no limit.
+	mu            sync.Mutex         // protects following fields.
+	tasks         map[*Task]struct{} // the pending tasks.
+	running       int                // the number of jobs running.
+	queue         jobQueue           // the fired tasks waiting for running.
+	idle          chan struct{}      // closed when the group becomes idle, created by Wait.
+}
+
+// NewGroup new task group on the timer.
+func (t *Timer) NewGroup(name string, opts ...GroupOption) *TaskGroup {
+	g := &TaskGroup{
+		name:  name,
+		timer: t,
+		tasks: make(map[*Task]struct{}),
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// Name return the name of the group.
+func (g *TaskGroup) Name() string { return g.name }
+
+// AfterFunc adds a function to the group.
+func (g *TaskGroup) AfterFunc(d time.Duration, f func()) (*Task, error) {
+	task := NewTask(d).WithJobFunc(f)
+	err := g.AddTask(task)
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// AddTask adds a task to the group and the timer.
+// It returns ErrGroupFull if the pending tasks reach the limit.
+func (g *TaskGroup) AddTask(task *Task) error {
+	g.mu.Lock()
+	_, pending := g.tasks[task]
+	if !pending {
+		if g.maxPending > 0 && len(g.tasks) >= g.maxPending {
+			g.mu.Unlock()
+			return ErrGroupFull
+		}
+		g.tasks[task] = struct{}{}
+	}
+	task.group.Store(g)
+	g.mu.Unlock()
+
+	// NOTE: add task without lock, the task may be dispatched immediately.
+	err := g.timer.AddTask(task)
+	if err != nil && !pending {
+		g.remove(task)
+	}
+	return err
+}
+
+// CancelAll cancel the pending tasks, and drop the fired tasks waiting for running.
+// It returns the number of tasks cancelled.
+func (g *TaskGroup) CancelAll() int {
+	g.mu.Lock()
+	tasks := make([]*Task, 0, len(g.tasks))
+	for task := range g.tasks {
+		tasks = append(tasks, task)
+	}
+	n := len(tasks) + g.queue.len()
+	g.queue.clear()
+	g.mu.Unlock()
+
+	for _, task := range tasks {
+		task.Cancel()
+	}
+	return n
+}
+
+// Wait blocks until the group is idle, no pending tasks and no jobs running or waiting for running.
+func (g *TaskGroup) Wait(ctx context.Context) error {
+	g.mu.Lock()
+	if g.isIdle() {
+		g.mu.Unlock()
+		return nil
+	}
+	if g.idle == nil {
+		g.idle = make(chan struct{})
+	}
+	idle := g.idle
+	g.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Len return the number of pending tasks.
+func (g *TaskGroup) Len() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.tasks)
+}
+
+// Running return the number of jobs running.
+func (g *TaskGroup) Running() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.running
+}
+
+// remove the pending task.
+func (g *TaskGroup) remove(task *Task) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.tasks[task]; ok {
+		delete(g.tasks, task)
+		g.notifyIdle()
+	}
+}
+
+// groupJob the job of a fired task waiting for running in the group.
+type groupJob struct {
+	job  Job
+	task *Task
+}
+
+// Run implements Job interface.
+func (j groupJob) Run() { j.job.Run() }
+
+// dispatch the job of the fired task, under the limit of the concurrent jobs of the group.
+func (g *TaskGroup) dispatch(job Job, task *Task) {
+	g.mu.Lock()
+	delete(g.tasks, task)
+	if g.maxConcurrent > 0 && g.running >= g.maxConcurrent {
+		g.queue.push(groupJob{job: job, task: task}, task.priority)
+		g.mu.Unlock()
+		return
+	}
+	g.running++
+	g.mu.Unlock()
+	if !g.timer.execute(JobFunc(func() { g.run(job) }), task) {
+		g.next()
+	}
+}
+
+// run the job, then hand the running slot over to the next queued job.
+func (g *TaskGroup) run(job Job) {
+	job.Run()
+	g.next()
+}
+
+// next execute the next queued task with the running slot, release the slot if none.
+func (g *TaskGroup) next() {
+	for {
+		g.mu.Lock()
+		job, ok := g.queue.pop()
+		if !ok {
+			g.running--
+			g.notifyIdle()
+			g.mu.Unlock()
+			return
+		}
+		g.mu.Unlock()
+		// NOTE: execute in the timer, the queued task may have a lane.
+		gj := job.(groupJob)
+		if g.timer.execute(JobFunc(func() { g.run(gj.job) }), gj.task) {
+			return
+		}
+	}
+}
+
+// NOTE: should be call when `TaskGroup.mu` lock.
+func (g *TaskGroup) isIdle() bool {
+	return len(g.tasks) == 0 && g.running == 0 && g.queue.len() == 0
+}
+
+// NOTE: should be call when `TaskGroup.mu` lock.
+func (g *TaskGroup) notifyIdle() {
+	if g.idle != nil && g.isIdle() {
+		close(g.idle)
+		g.idle = nil
+	}
+}
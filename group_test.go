@@ -0,0 +1,111 @@
+package timer
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TaskGroup(t *testing.T) {
+	tm := NewTimer()
+	g := tm.NewGroup("tenant", WithGroupMaxPending(3))
+	require.Equal(t, "tenant", g.Name())
+	_, err := g.AfterFunc(time.Millisecond, func() {})
+	require.ErrorIs(t, err, ErrClosed)
+	require.Zero(t, g.Len())
+
+	tm.Start()
+	defer tm.Stop()
+
+	var fired atomic.Int64
+	for i := 0; i < 3; i++ {
+		_, err = g.AfterFunc(time.Duration(i+1)*10*time.Millisecond, func() { fired.Add(1) })
+		require.NoError(t, err)
+	}
+	_, err = g.AfterFunc(time.Millisecond, func() {})
+	require.ErrorIs(t, err, ErrGroupFull)
+	require.Equal(t, 3, g.Len())
+
+	// removed automatically when fired.
+	require.NoError(t, g.Wait(context.Background()))
+	require.Equal(t, int64(3), fired.Load())
+	require.Zero(t, g.Len())
+
+	// removed automatically when cancelled.
+	task, err := g.AfterFunc(time.Hour, func() {})
+	require.NoError(t, err)
+	require.Equal(t, 1, g.Len())
+	require.NoError(t, g.AddTask(task)) // re-add
+	require.Equal(t, 1, g.Len())
+	task.Cancel()
+	require.Zero(t, g.Len())
+	require.NoError(t, g.Wait(context.Background()))
+}
+
+func Test_TaskGroup_CancelAll(t *testing.T) {
+	tm := NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	g := tm.NewGroup("request")
+	var fired atomic.Int64
+	for i := 0; i < 10; i++ {
+		_, err := g.AfterFunc(20*time.Millisecond, func() { fired.Add(1) })
+		require.NoError(t, err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, g.Wait(ctx), context.DeadlineExceeded)
+
+	require.Equal(t, 10, g.CancelAll())
+	require.Zero(t, g.Len())
+	require.NoError(t, g.Wait(context.Background()))
+	time.Sleep(40 * time.Millisecond)
+	require.Zero(t, fired.Load())
+}
+
+func Test_TaskGroup_MaxConcurrent(t *testing.T) {
+	tm := NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	g := tm.NewGroup("bulk", WithGroupMaxConcurrent(2))
+	var running, peak, done atomic.Int64
+	for i := 0; i < 10; i++ {
+		_, err := g.AfterFunc(5*time.Millisecond, func() {
+			n := running.Add(1)
+			for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+			}
+			time.Sleep(5 * time.Millisecond)
+			running.Add(-1)
+			done.Add(1)
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, g.Wait(context.Background()))
+	require.Equal(t, int64(10), done.Load())
+	require.LessOrEqual(t, peak.Load(), int64(2))
+	require.Zero(t, g.Running())
+}
+
+func Test_TaskGroup_TimerSkip(t *testing.T) {
+	tm := NewTimer(WithMaxConcurrentJobs(1, BusyPolicy_Skip))
+	tm.Start()
+	defer tm.Stop()
+
+	g := tm.NewGroup("skip")
+	var done atomic.Int64
+	for i := 0; i < 5; i++ {
+		_, err := g.AfterFunc(5*time.Millisecond, func() {
+			time.Sleep(5 * time.Millisecond)
+			done.Add(1)
+		})
+		require.NoError(t, err)
+	}
+	// the skipped jobs are not counted as running.
+	require.NoError(t, g.Wait(context.Background()))
+	require.Equal(t, int64(5), done.Load()+tm.SkippedJobs())
+}
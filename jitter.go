@@ -0,0 +1,51 @@
+package timer
+
+import (
+	"math/rand/v2"
+	"sync"
+)
+
+// WithSpreading spread the expiration of the tasks without jitter by a random delay
+// in [0, fraction*delay], to avoid the tasks scheduled with the same delay firing together.
+// 0 means no spreading. the tasks created by this module are not spread, see Task.WithNoSpreading.
+func WithSpreading(fraction float64) Option {
+	return func(t *Timer) {
+		t.jitter.spreading = fraction
+	}
+}
+
+// WithRandSource set the random source of the jitter, it makes the jitter deterministic for tests.
+// default use the global random source of math/rand/v2.
+func WithRandSource(src rand.Source) Option {
+	return func(t *Timer) {
+		t.jitter.rand = rand.New(src)
+	}
+}
+
+// jitterSource generates the random delay of the tasks.
+type jitterSource struct {
+	spreading float64    // the default jitter fraction of the tasks without jitter.
+	mu        sync.Mutex // protects rand, which is not safe for concurrent use.
+	rand      *rand.Rand // the random source, nil means the global one.
+}
+
+// jitterMs returns a random delay in [0, max] of the task, unit is milliseconds.
+func (j *jitterSource) jitterMs(task *Task, delayMs int64) int64 {
+	var maxMs int64
+	if task.jitter == 0 && task.jitterFraction == 0 {
+		if !task.noSpreading {
+			maxMs = int64(j.spreading * float64(delayMs))
+		}
+	} else {
+		maxMs = max(task.jitter.Milliseconds(), int64(task.jitterFraction*float64(delayMs)))
+	}
+	if maxMs <= 0 {
+		return 0
+	}
+	if j.rand == nil {
+		return rand.Int64N(maxMs + 1)
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.rand.Int64N(maxMs + 1)
+}
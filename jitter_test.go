@@ -0,0 +1,117 @@
+package timer
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Jitter(t *testing.T) {
+	t.Run("bounds", func(t *testing.T) {
+		j := &jitterSource{}
+		tasks := map[int64]*Task{
+			0:    NewTask(time.Second),
+			100:  NewTask(time.Second).WithJitter(100 * time.Millisecond),
+			500:  NewTask(time.Second).WithJitterFraction(0.5),
+			1000: NewTask(time.Second).WithJitter(time.Second).WithJitterFraction(0.5),
+		}
+		for maxMs, task := range tasks {
+			for range 1000 {
+				v := j.jitterMs(task, 1000)
+				require.GreaterOrEqual(t, v, int64(0))
+				require.LessOrEqual(t, v, maxMs)
+			}
+		}
+	})
+
+	t.Run("spreading", func(t *testing.T) {
+		j := &jitterSource{spreading: 0.1}
+		seen := make(map[int64]struct{})
+		for range 1000 {
+			v := j.jitterMs(NewTask(time.Second), 1000)
+			require.LessOrEqual(t, v, int64(100))
+			seen[v] = struct{}{}
+		}
+		require.Greater(t, len(seen), 50)
+		// the task's own jitter takes precedence.
+		require.LessOrEqual(t, j.jitterMs(NewTask(time.Second).WithJitter(time.Millisecond), 1000), int64(1))
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		t1 := NewTimer(WithSpreading(0.5), WithRandSource(rand.NewPCG(1, 2)))
+		t2 := NewTimer(WithSpreading(0.5), WithRandSource(rand.NewPCG(1, 2)))
+		for range 100 {
+			task := NewTask(time.Minute)
+			require.Equal(t, t1.jitter.jitterMs(task, 60000), t2.jitter.jitterMs(task, 60000))
+		}
+	})
+}
+
+func Test_Timer_Jitter(t *testing.T) {
+	tm := NewTimer(WithRandSource(rand.NewPCG(1, 2)))
+	tm.Start()
+	defer tm.Stop()
+
+	expirations := make(map[int64]struct{})
+	for range 100 {
+		task := NewTask(time.Hour).WithJitter(time.Minute)
+		require.NoError(t, tm.AddTask(task))
+		expirations[task.taskEntry.expirationMs] = struct{}{}
+		task.Cancel()
+	}
+	// the tasks with the same delay are spread out.
+	require.Greater(t, len(expirations), 90)
+
+	fired := make(chan struct{})
+	_, err := tm.AfterFunc(10*time.Millisecond, func() { close(fired) })
+	require.NoError(t, err)
+	task := NewTask(10 * time.Millisecond).WithJitter(20 * time.Millisecond).WithJobFunc(func() {})
+	require.NoError(t, tm.AddTask(task))
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		require.Fail(t, "task not fired")
+	}
+	require.Eventually(t, func() bool { return !task.Activated() }, time.Second, time.Millisecond)
+}
+
+func Test_Timer_Spreading_NoSpreading(t *testing.T) {
+	tm := NewTimer(WithSpreading(0.5), WithRandSource(rand.NewPCG(1, 2)))
+	tm.Start()
+	defer tm.Stop()
+
+	jitterMs := func(task *Task) int64 {
+		task.rw.RLock()
+		defer task.rw.RUnlock()
+		return task.taskEntry.jitterMs
+	}
+	// the tasks created by the timer fire on time.
+	st := tm.NewStdTimer(time.Hour)
+	defer st.Stop()
+	ticker := tm.NewStdTicker(time.Hour)
+	defer ticker.Stop()
+	_, after := tm.After(time.Hour)
+	defer after.Cancel()
+	for _, task := range []*Task{st.task, ticker.task, after} {
+		require.Zero(t, jitterMs(task))
+	}
+
+	// the user tasks are spread, unless opt out.
+	var spread int
+	for range 10 {
+		task, err := tm.AfterFunc(time.Hour, func() {})
+		require.NoError(t, err)
+		if jitterMs(task) > 0 {
+			spread++
+		}
+		task.Cancel()
+
+		task = NewTask(time.Hour).WithNoSpreading()
+		require.NoError(t, tm.AddTask(task))
+		require.Zero(t, jitterMs(task))
+		task.Cancel()
+	}
+	require.Positive(t, spread)
+}
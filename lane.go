@@ -0,0 +1,81 @@
+package timer
+
+import "sync"
+
+// laneExecutor runs the jobs sharing a lane key sequentially, in dispatch order,
+// while different lanes run in parallel.
+type laneExecutor struct {
+	mu    sync.Mutex       // protects following fields.
+	lanes map[string]*lane // the busy lanes, created lazily.
+}
+
+// lane a busy lane, the running job is not in the queue.
+type lane struct {
+	queue []Job // the jobs waiting for running, FIFO.
+}
+
+// Lanes return the number of busy lanes.
+func (t *Timer) Lanes() int {
+	t.lanes.mu.Lock()
+	defer t.lanes.mu.Unlock()
+	return len(t.lanes.lanes)
+}
+
+// execute run the job of the task, in the task's lane if it has one.
+// It returns false if the job is skipped.
+func (t *Timer) execute(job Job, task *Task) bool {
+	if task.lane == "" {
+		return t.submit(job, task.priority, task.skippable())
+	}
+	t.lanes.mu.Lock()
+	if l, ok := t.lanes.lanes[task.lane]; ok {
+		l.queue = append(l.queue, job)
+		t.lanes.mu.Unlock()
+		return true
+	}
+	if t.lanes.lanes == nil {
+		t.lanes.lanes = make(map[string]*lane)
+	}
+	l := &lane{}
+	t.lanes.lanes[task.lane] = l
+	t.lanes.mu.Unlock()
+	// NOTE: submit without the lock, the `GoPool` may block until a running lane job finished.
+	if t.submit(JobFunc(func() { t.runLane(task.lane, l, job) }), task.priority, task.skippable()) {
+		return true
+	}
+	// the job is skipped, unregister the lane, unless the jobs queued meanwhile, which are accepted.
+	t.lanes.mu.Lock()
+	if len(l.queue) == 0 {
+		delete(t.lanes.lanes, task.lane)
+		t.lanes.mu.Unlock()
+		return false
+	}
+	job = l.popJob()
+	t.lanes.mu.Unlock()
+	t.submit(JobFunc(func() { t.runLane(task.lane, l, job) }), task.priority, false)
+	return false
+}
+
+// runLane run the job, then the queued jobs of the lane until the queue is empty.
+func (t *Timer) runLane(key string, l *lane, job Job) {
+	for {
+		job.Run()
+		t.lanes.mu.Lock()
+		if len(l.queue) == 0 {
+			delete(t.lanes.lanes, key)
+			t.lanes.mu.Unlock()
+			return
+		}
+		job = l.popJob()
+		t.lanes.mu.Unlock()
+	}
+}
+
+// popJob pop the first queued job.
+// NOTE: should be call when `laneExecutor.mu` lock.
+func (l *lane) popJob() Job {
+	job := l.queue[0]
+	l.queue[0] = nil // avoid memory leaks
+	l.queue = l.queue[1:]
+	return job
+}
@@ -0,0 +1,182 @@
+package timer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// laneRecorder records the run order of each lane, and detects the concurrent runs of a lane.
+type laneRecorder struct {
+	mu         sync.Mutex
+	order      map[string][]int
+	running    map[string]bool
+	concurrent atomic.Int64
+	total      atomic.Int64
+}
+
+func newLaneRecorder() *laneRecorder {
+	return &laneRecorder{
+		order:   make(map[string][]int),
+		running: make(map[string]bool),
+	}
+}
+
+func (r *laneRecorder) job(lane string, seq int) func() {
+	return func() {
+		r.mu.Lock()
+		if r.running[lane] {
+			r.concurrent.Add(1)
+		}
+		r.running[lane] = true
+		r.mu.Unlock()
+
+		time.Sleep(100 * time.Microsecond)
+
+		r.mu.Lock()
+		r.running[lane] = false
+		r.order[lane] = append(r.order[lane], seq)
+		r.mu.Unlock()
+		r.total.Add(1)
+	}
+}
+
+func Test_Timer_Lane(t *testing.T) {
+	const lanes, tasksPerLane = 20, 50
+
+	tm := NewTimer(WithMaxConcurrentJobs(8, BusyPolicy_Queue))
+	tm.Start()
+	defer tm.Stop()
+
+	r := newLaneRecorder()
+	var wg sync.WaitGroup
+	for l := 0; l < lanes; l++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lane := fmt.Sprintf("lane-%d", l)
+			for i := 0; i < tasksPerLane; i++ {
+				// several tasks of a lane expire at the same time.
+				task := NewTask(time.Duration(i/5) * time.Millisecond).
+					WithLane(lane).
+					WithJobFunc(r.job(lane, i))
+				require.Equal(t, lane, task.Lane())
+				require.NoError(t, tm.AddTask(task))
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Eventually(t, func() bool { return r.total.Load() == lanes*tasksPerLane }, 5*time.Second, time.Millisecond)
+	require.Eventually(t, func() bool { return tm.Lanes() == 0 }, time.Second, time.Millisecond)
+	require.Zero(t, r.concurrent.Load())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	require.Len(t, r.order, lanes)
+	for lane, order := range r.order {
+		for i, seq := range order {
+			require.Equal(t, i, seq, "lane %s out of order: %v", lane, order)
+		}
+	}
+}
+
+func Test_Timer_Lane_Parallel(t *testing.T) {
+	tm := NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	// different lanes run in parallel.
+	release := make(chan struct{})
+	var started atomic.Int64
+	for _, lane := range []string{"a", "b"} {
+		require.NoError(t, tm.AddTask(NewTaskFunc(time.Millisecond, func() {
+			started.Add(1)
+			<-release
+		}).WithLane(lane)))
+	}
+	require.Eventually(t, func() bool { return started.Load() == 2 }, time.Second, time.Millisecond)
+	require.Equal(t, 2, tm.Lanes())
+	close(release)
+	require.Eventually(t, func() bool { return tm.Lanes() == 0 }, time.Second, time.Millisecond)
+}
+
+func Test_Timer_Lane_Group(t *testing.T) {
+	tm := NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	r := newLaneRecorder()
+	g := tm.NewGroup("lanes", WithGroupMaxConcurrent(4))
+	for i := 0; i < 30; i++ {
+		lane := fmt.Sprintf("lane-%d", i%3)
+		require.NoError(t, g.AddTask(NewTaskFunc(time.Duration(i/10)*time.Millisecond, r.job(lane, i/3)).WithLane(lane)))
+	}
+	require.NoError(t, g.Wait(context.Background()))
+	require.Equal(t, int64(30), r.total.Load())
+	require.Zero(t, r.concurrent.Load())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for lane, order := range r.order {
+		for i, seq := range order {
+			require.Equal(t, i, seq, "lane %s out of order: %v", lane, order)
+		}
+	}
+}
+
+// blockingPool runs at most cap(sem) functions at once, Go blocks until a slot is free.
+type blockingPool struct {
+	sem chan struct{}
+}
+
+func (p blockingPool) Go(f func()) {
+	p.sem <- struct{}{}
+	go func() {
+		defer func() { <-p.sem }()
+		f()
+	}()
+}
+
+func Test_Timer_Lane_BlockingPool(t *testing.T) {
+	tm := NewTimer(WithGoPool(blockingPool{sem: make(chan struct{}, 1)}))
+	tm.Start()
+	defer tm.Stop()
+
+	var done atomic.Int64
+	for i, lane := range []string{"a", "b", "a"} {
+		require.NoError(t, tm.AddTask(NewTaskFunc(time.Duration(i)*time.Millisecond, func() {
+			time.Sleep(10 * time.Millisecond)
+			done.Add(1)
+		}).WithLane(lane)))
+	}
+	require.Eventually(t, func() bool { return done.Load() == 3 }, time.Second, time.Millisecond)
+	require.Eventually(t, func() bool { return tm.Lanes() == 0 }, time.Second, time.Millisecond)
+}
+
+func Test_Timer_Lane_Skip(t *testing.T) {
+	tm := NewTimer(WithMaxConcurrentJobs(1, BusyPolicy_Skip))
+	tm.Start()
+	defer tm.Stop()
+
+	release := make(chan struct{})
+	_, err := tm.AfterFunc(0, func() { <-release })
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return tm.RunningJobs() == 1 }, time.Second, time.Millisecond)
+
+	require.NoError(t, tm.AddTask(NewTaskFunc(0, func() { t.Error("skipped task should not run") }).WithLane("a")))
+	require.Eventually(t, func() bool { return tm.SkippedJobs() == 1 }, time.Second, time.Millisecond)
+	require.Zero(t, tm.Lanes())
+
+	// the lane is available again.
+	close(release)
+	require.Eventually(t, func() bool { return tm.RunningJobs() == 0 }, time.Second, time.Millisecond)
+	ran := make(chan struct{})
+	require.NoError(t, tm.AddTask(NewTaskFunc(0, func() { close(ran) }).WithLane("a")))
+	<-ran
+}
@@ -0,0 +1,184 @@
+// Package lease grants leases on resources which auto-expire unless renewed,
+// each grant carries a fencing token, so stale holders can be detected.
+package lease
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thinkgos/timer"
+)
+
+var (
+	// ErrHeld is returned when the lease is held by another holder.
+	ErrHeld = errors.New("lease: already held")
+	// ErrNotFound is returned when the lease is not found, it may be expired or revoked.
+	ErrNotFound = errors.New("lease: not found")
+	// ErrStaleToken is returned when the fencing token is not the one of the lease currently held,
+	// the lease of the holder has expired and may be granted to another holder.
+	ErrStaleToken = errors.New("lease: stale token")
+)
+
+// Lease a granted lease.
+type Lease[K comparable] struct {
+	ID        K
+	Token     uint64        // the fencing token, increases on each grant.
+	TTL       time.Duration // the time to live.
+	ExpiresAt time.Time     // the expiry time.
+}
+
+// Option customize the Manager.
+type Option[K comparable] func(*Manager[K])
+
+// WithOnExpired set the callback, which is called through the timer's `GoPool` when a lease expires.
+func WithOnExpired[K comparable](f func(Lease[K])) Option[K] {
+	return func(m *Manager[K]) {
+		m.onExpired = f
+	}
+}
+
+// WithExpiredChan deliver the expired leases to the channel, see Manager.Expired.
+// The delivery blocks the timer's `GoPool` until received, so the receiver should keep up.
+func WithExpiredChan[K comparable](size int) Option[K] {
+	return func(m *Manager[K]) {
+		m.expired = make(chan Lease[K], size)
+	}
+}
+
+type entry[K comparable] struct {
+	lease Lease[K]
+	task  *timer.Task
+}
+
+// Manager grants and tracks the leases, it is safe for concurrent use.
+type Manager[K comparable] struct {
+	timer     *timer.Timer
+	onExpired func(Lease[K])
+	expired   chan Lease[K]
+	mu        sync.Mutex // protects following fields.
+	token     uint64     // the last fencing token.
+	leases    map[K]*entry[K]
+}
+
+// New new lease manager, the expiries are scheduled on the timer t.
+func New[K comparable](t *timer.Timer, opts ...Option[K]) *Manager[K] {
+	m := &Manager[K]{
+		timer:  t,
+		leases: make(map[K]*entry[K]),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Grant a lease of the id with ttl, it returns ErrHeld if the lease is held.
+func (m *Manager[K]) Grant(id K, ttl time.Duration) (Lease[K], error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.leases[id]; ok {
+		return Lease[K]{}, ErrHeld
+	}
+	e := &entry[K]{
+		lease: Lease[K]{
+			ID:        id,
+			Token:     m.token + 1,
+			TTL:       ttl,
+			ExpiresAt: time.Now().Add(ttl),
+		},
+	}
+	e.task = timer.NewTaskFunc(ttl, func() { m.expire(e) }).WithNoSkip().WithNoSpreading()
+	if err := m.timer.AddTask(e.task); err != nil {
+		return Lease[K]{}, err
+	}
+	m.token++
+	m.leases[id] = e
+	return e.lease, nil
+}
+
+// Renew the lease of the id with its ttl, the fencing token is unchanged.
+// It returns ErrStaleToken if the token is not the one of the lease currently held.
+func (m *Manager[K]) Renew(id K, token uint64) (Lease[K], error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.leases[id]
+	if !ok {
+		return Lease[K]{}, ErrNotFound
+	}
+	if e.lease.Token != token {
+		return Lease[K]{}, ErrStaleToken
+	}
+	if err := m.timer.AddTask(e.task); err != nil {
+		return Lease[K]{}, err
+	}
+	e.lease.ExpiresAt = time.Now().Add(e.lease.TTL)
+	return e.lease, nil
+}
+
+// Revoke the lease of the id, the expiry notification is not delivered.
+// It returns ErrStaleToken if the token is not the one of the lease currently held.
+func (m *Manager[K]) Revoke(id K, token uint64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.leases[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if e.lease.Token != token {
+		return ErrStaleToken
+	}
+	e.task.Cancel()
+	delete(m.leases, id)
+	return nil
+}
+
+// Get the lease of the id.
+func (m *Manager[K]) Get(id K) (Lease[K], bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.leases[id]
+	if !ok {
+		return Lease[K]{}, false
+	}
+	return e.lease, true
+}
+
+// Valid reports whether the token is the fencing token of the lease currently held on the id.
+func (m *Manager[K]) Valid(id K, token uint64) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.leases[id]
+	return ok && e.lease.Token == token
+}
+
+// Len returns the number of the held leases.
+func (m *Manager[K]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.leases)
+}
+
+// Expired returns the channel on which the expired leases are delivered,
+// nil if WithExpiredChan is not set.
+func (m *Manager[K]) Expired() <-chan Lease[K] {
+	return m.expired
+}
+
+func (m *Manager[K]) expire(e *entry[K]) {
+	m.mu.Lock()
+	// revoked, or renewed.
+	if m.leases[e.lease.ID] != e || e.task.Activated() {
+		m.mu.Unlock()
+		return
+	}
+	delete(m.leases, e.lease.ID)
+	m.mu.Unlock()
+
+	if m.onExpired != nil {
+		m.onExpired(e.lease)
+	}
+	if m.expired != nil {
+		m.expired <- e.lease
+	}
+}
@@ -0,0 +1,86 @@
+package lease
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thinkgos/timer"
+)
+
+func Test_Manager(t *testing.T) {
+	tm := timer.NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	var expiredCount atomic.Int64
+	m := New(tm,
+		WithOnExpired(func(Lease[string]) { expiredCount.Add(1) }),
+		WithExpiredChan[string](1),
+	)
+
+	l1, err := m.Grant("lock", 30*time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, "lock", l1.ID)
+	require.Equal(t, uint64(1), l1.Token)
+	_, err = m.Grant("lock", time.Second)
+	require.ErrorIs(t, err, ErrHeld)
+	require.True(t, m.Valid("lock", l1.Token))
+
+	// renew keeps it alive.
+	for i := 0; i < 5; i++ {
+		time.Sleep(15 * time.Millisecond)
+		l, err := m.Renew("lock", l1.Token)
+		require.NoError(t, err)
+		require.Equal(t, l1.Token, l.Token)
+		require.True(t, l.ExpiresAt.After(l1.ExpiresAt))
+	}
+	l, ok := m.Get("lock")
+	require.True(t, ok)
+	require.Equal(t, l1.Token, l.Token)
+
+	// expire
+	expired := <-m.Expired()
+	require.Equal(t, l1.Token, expired.Token)
+	require.Equal(t, int64(1), expiredCount.Load())
+	require.False(t, m.Valid("lock", l1.Token))
+	_, err = m.Renew("lock", l1.Token)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// the new holder gets a newer token, the stale holder is detected.
+	l2, err := m.Grant("lock", time.Second)
+	require.NoError(t, err)
+	require.Greater(t, l2.Token, l1.Token)
+	require.False(t, m.Valid("lock", l1.Token))
+	require.True(t, m.Valid("lock", l2.Token))
+	require.Equal(t, 1, m.Len())
+	// the stale holder can't renew or revoke the lease of the new holder.
+	_, err = m.Renew("lock", l1.Token)
+	require.ErrorIs(t, err, ErrStaleToken)
+	require.ErrorIs(t, m.Revoke("lock", l1.Token), ErrStaleToken)
+	require.True(t, m.Valid("lock", l2.Token))
+
+	// revoke
+	require.NoError(t, m.Revoke("lock", l2.Token))
+	require.ErrorIs(t, m.Revoke("lock", l2.Token), ErrNotFound)
+	require.Zero(t, m.Len())
+	_, ok = m.Get("lock")
+	require.False(t, ok)
+}
+
+func Test_Manager_TimerClosed(t *testing.T) {
+	tm := timer.NewTimer()
+	m := New[int](tm)
+	require.Nil(t, m.Expired())
+	_, err := m.Grant(1, time.Second)
+	require.ErrorIs(t, err, timer.ErrClosed)
+	require.Zero(t, m.Len())
+
+	// the token is not consumed by the failed grant.
+	tm.Start()
+	defer tm.Stop()
+	l, err := m.Grant(1, time.Second)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), l.Token)
+}
@@ -0,0 +1,122 @@
+package timer
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// MisfirePolicy the policy of the task fires later than the misfire threshold,
+// when the advance goroutine falls behind or the process is suspended.
+type MisfirePolicy int
+
+const (
+	MisfirePolicy_RunAnyway MisfirePolicy = iota // run the job once, as if it fired on time.
+	MisfirePolicy_Skip                           // skip the job.
+	MisfirePolicy_RunOnce                        // run the job once for all the missed periods, see MissedPeriods.
+	MisfirePolicy_Handler                        // call the misfire handler instead of the job, skip if no handler.
+)
+
+// String implements fmt.Stringer.
+func (p MisfirePolicy) String() string {
+	switch p {
+	case MisfirePolicy_RunAnyway:
+		return "run-anyway"
+	case MisfirePolicy_Skip:
+		return "skip"
+	case MisfirePolicy_RunOnce:
+		return "run-once"
+	case MisfirePolicy_Handler:
+		return "handler"
+	default:
+		return "unknown"
+	}
+}
+
+// WithMisfireThreshold set the default misfire threshold and policy of the tasks, 0 means no misfire handling.
+// the task fires later than its expiry plus tolerance plus threshold is a misfire, see Task.WithMisfireThreshold.
+func WithMisfireThreshold(threshold time.Duration, policy MisfirePolicy) Option {
+	return func(t *Timer) {
+		t.misfire.threshold = threshold
+		t.misfire.policy = policy
+	}
+}
+
+// WithOnMisfire set the default misfire handler of the tasks, used with MisfirePolicy_Handler.
+func WithOnMisfire(f func(task *Task, lateness time.Duration)) Option {
+	return func(t *Timer) {
+		t.misfire.onMisfire = f
+	}
+}
+
+// misfireHandling the default misfire handling of the tasks.
+type misfireHandling struct {
+	threshold time.Duration                            // the misfire threshold, 0 means no misfire handling.
+	policy    MisfirePolicy                            // the misfire policy.
+	onMisfire func(task *Task, lateness time.Duration) // the misfire handler.
+	misfires  atomic.Int64                             // the total number of misfires.
+}
+
+// Misfires return the total number of misfires detected.
+func (t *Timer) Misfires() int64 { return t.misfire.misfires.Load() }
+
+// fire dispatch the job of the expired task entry, with the misfire policy if it fires late.
+// the job of the task not skippable is always dispatched.
+func (t *Timer) fire(te *taskEntry) {
+	task := te.task
+	threshold, policy := task.misfire, task.misfirePolicy
+	if threshold <= 0 {
+		threshold, policy = t.misfire.threshold, t.misfire.policy
+	}
+	if threshold <= 0 || !task.skippable() {
+		t.dispatch(task, task)
+		return
+	}
+	lateness := time.Duration(t.clock.nowMs()-te.expirationMs) * time.Millisecond
+	if lateness <= task.tolerance+threshold {
+		t.dispatch(task, task)
+		return
+	}
+	t.misfire.misfires.Add(1)
+	switch policy {
+	case MisfirePolicy_RunAnyway:
+		t.dispatch(task, task)
+	case MisfirePolicy_RunOnce:
+		var missed int
+		if period := task.Delay(); period > 0 && task.atMs.Load() == 0 {
+			missed = int(lateness / period)
+		}
+		t.dispatch(JobFunc(func() {
+			task.runContext(context.WithValue(context.Background(), missedPeriodsKey{}, missed))
+		}), task)
+	case MisfirePolicy_Handler:
+		onMisfire := task.onMisfire
+		if onMisfire == nil {
+			onMisfire = t.misfire.onMisfire
+		}
+		if onMisfire == nil {
+			t.skip(task)
+		} else {
+			t.dispatch(JobFunc(func() { onMisfire(task, lateness) }), task)
+		}
+	default:
+		t.skip(task)
+	}
+}
+
+// missedPeriodsKey the context key of the number of the missed periods.
+type missedPeriodsKey struct{}
+
+// MissedPeriods return the number of the periods missed, the period is the delay of the task,
+// only for the job aware of the context, fired late with MisfirePolicy_RunOnce, otherwise 0.
+func MissedPeriods(ctx context.Context) int {
+	missed, _ := ctx.Value(missedPeriodsKey{}).(int)
+	return missed
+}
+
+// skip the job of the expired task.
+func (t *Timer) skip(task *Task) {
+	if g := task.group.Load(); g != nil {
+		g.remove(task)
+	}
+}
@@ -0,0 +1,153 @@
+package timer
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// blockAdvance blocks the advance goroutine of the timer for d, so that the tasks fire late.
+func blockAdvance(t *testing.T, tm *Timer, d time.Duration) {
+	blocker := NewTaskFunc(time.Millisecond, func() { time.Sleep(d) })
+	blocker.inline = true
+	require.NoError(t, tm.AddTask(blocker))
+}
+
+func Test_Misfire(t *testing.T) {
+	t.Run("not late", func(t *testing.T) {
+		tm := NewTimer(WithMisfireThreshold(50*time.Millisecond, MisfirePolicy_Skip))
+		tm.Start()
+		defer tm.Stop()
+
+		var fired atomic.Int64
+		_, err := tm.AfterFunc(10*time.Millisecond, func() { fired.Add(1) })
+		require.NoError(t, err)
+		require.Eventually(t, func() bool { return fired.Load() == 1 }, time.Second, time.Millisecond)
+		require.Zero(t, tm.Misfires())
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		tm := NewTimer(WithMisfireThreshold(20*time.Millisecond, MisfirePolicy_Skip))
+		tm.Start()
+		defer tm.Stop()
+
+		g := tm.NewGroup("misfire")
+		var fired atomic.Int64
+		blockAdvance(t, tm, 100*time.Millisecond)
+		_, err := g.AfterFunc(10*time.Millisecond, func() { fired.Add(1) })
+		require.NoError(t, err)
+		require.Eventually(t, func() bool { return tm.Misfires() == 1 }, time.Second, time.Millisecond)
+		require.NoError(t, g.Wait(context.Background()))
+		time.Sleep(10 * time.Millisecond)
+		require.Zero(t, fired.Load())
+	})
+
+	t.Run("run anyway", func(t *testing.T) {
+		tm := NewTimer(WithMisfireThreshold(20*time.Millisecond, MisfirePolicy_RunAnyway))
+		tm.Start()
+		defer tm.Stop()
+
+		var fired, missed atomic.Int64
+		blockAdvance(t, tm, 100*time.Millisecond)
+		task := NewTaskContextFunc(10*time.Millisecond, func(ctx context.Context) error {
+			missed.Store(int64(MissedPeriods(ctx)))
+			fired.Add(1)
+			return nil
+		})
+		require.NoError(t, tm.AddTask(task))
+		require.Eventually(t, func() bool { return tm.Misfires() == 1 }, time.Second, time.Millisecond)
+		require.Eventually(t, func() bool { return fired.Load() == 1 }, time.Second, time.Millisecond)
+		time.Sleep(10 * time.Millisecond)
+		require.Equal(t, int64(1), fired.Load())
+		// as if it fired on time.
+		require.Zero(t, missed.Load())
+	})
+
+	t.Run("run once", func(t *testing.T) {
+		tm := NewTimer(WithMisfireThreshold(20*time.Millisecond, MisfirePolicy_RunOnce))
+		tm.Start()
+		defer tm.Stop()
+
+		var fired, missed atomic.Int64
+		blockAdvance(t, tm, 100*time.Millisecond)
+		task := NewTaskContextFunc(10*time.Millisecond, func(ctx context.Context) error {
+			missed.Store(int64(MissedPeriods(ctx)))
+			fired.Add(1)
+			return nil
+		})
+		require.NoError(t, tm.AddTask(task))
+		require.Eventually(t, func() bool { return fired.Load() == 1 }, time.Second, time.Millisecond)
+		time.Sleep(10 * time.Millisecond)
+		require.Equal(t, int64(1), fired.Load())
+		require.Equal(t, int64(1), tm.Misfires())
+		// about 90ms late, the missed periods of 10ms are reported to the job.
+		require.GreaterOrEqual(t, missed.Load(), int64(5))
+	})
+
+	t.Run("handler", func(t *testing.T) {
+		var timerLateness atomic.Int64
+		tm := NewTimer(
+			WithMisfireThreshold(20*time.Millisecond, MisfirePolicy_Handler),
+			WithOnMisfire(func(task *Task, lateness time.Duration) { timerLateness.Store(int64(lateness)) }),
+		)
+		tm.Start()
+		defer tm.Stop()
+
+		var fired atomic.Int64
+		var taskLateness atomic.Int64
+		blockAdvance(t, tm, 100*time.Millisecond)
+		_, err := tm.AfterFunc(10*time.Millisecond, func() { fired.Add(1) })
+		require.NoError(t, err)
+		task := NewTaskFunc(10*time.Millisecond, func() { fired.Add(1) }).
+			WithOnMisfire(func(task *Task, lateness time.Duration) { taskLateness.Store(int64(lateness)) })
+		require.NoError(t, tm.AddTask(task))
+		require.Eventually(t, func() bool {
+			return timerLateness.Load() > int64(20*time.Millisecond) && taskLateness.Load() > int64(20*time.Millisecond)
+		}, time.Second, time.Millisecond)
+		require.Zero(t, fired.Load())
+		require.Equal(t, int64(2), tm.Misfires())
+	})
+
+	t.Run("task override", func(t *testing.T) {
+		tm := NewTimer(WithMisfireThreshold(20*time.Millisecond, MisfirePolicy_Skip))
+		tm.Start()
+		defer tm.Stop()
+
+		var fired atomic.Int64
+		blockAdvance(t, tm, 100*time.Millisecond)
+		task := NewTaskFunc(10*time.Millisecond, func() { fired.Add(1) }).
+			WithMisfireThreshold(time.Second, MisfirePolicy_Skip)
+		require.NoError(t, tm.AddTask(task))
+		require.Eventually(t, func() bool { return fired.Load() == 1 }, time.Second, time.Millisecond)
+		require.Zero(t, tm.Misfires())
+	})
+
+	t.Run("ticker keeps ticking", func(t *testing.T) {
+		tm := NewTimer(WithMisfireThreshold(20*time.Millisecond, MisfirePolicy_Skip))
+		tm.Start()
+		defer tm.Stop()
+
+		blockAdvance(t, tm, 100*time.Millisecond)
+		ticker := tm.NewStdTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for range 3 {
+			select {
+			case <-ticker.C:
+			case <-time.After(time.Second):
+				t.Fatal("the ticker should keep ticking after a misfire")
+			}
+		}
+		require.Zero(t, tm.Misfires())
+	})
+}
+
+func Test_MisfirePolicy_String(t *testing.T) {
+	require.Equal(t, "run-anyway", MisfirePolicy_RunAnyway.String())
+	require.Equal(t, "skip", MisfirePolicy_Skip.String())
+	require.Equal(t, "run-once", MisfirePolicy_RunOnce.String())
+	require.Equal(t, "handler", MisfirePolicy_Handler.String())
+	require.Equal(t, "unknown", MisfirePolicy(100).String())
+}
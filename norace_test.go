@@ -0,0 +1,6 @@
+//go:build !race
+
+package timer
+
+// raceEnabled the race detector is enabled, `sync.Pool` drops the items randomly.
+const raceEnabled = false
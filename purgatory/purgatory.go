@@ -0,0 +1,188 @@
+// Package purgatory implements Kafka's delayed operation purgatory on top of the timer,
+// which holds the operations that can not be completed yet,
+// until they are completed by other events or expired.
+package purgatory
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/thinkgos/timer"
+)
+
+// DelayedOperation an operation with delayed completion.
+type DelayedOperation interface {
+	// Delay the timeout of the operation.
+	Delay() time.Duration
+	// TryComplete checks whether the operation can be completed now,
+	// it is never called concurrently for the same operation.
+	TryComplete() bool
+	// OnComplete is called exactly once when the operation is completed,
+	// either by TryComplete or by expiration.
+	OnComplete()
+	// OnExpiration is called after OnComplete when the operation is expired.
+	OnExpiration()
+}
+
+// operation the state of a delayed operation in the purgatory.
+type operation[K comparable] struct {
+	DelayedOperation
+	task      *timer.Task
+	completed atomic.Bool
+	mu        sync.Mutex      // serializes TryComplete.
+	watched   bool            // protected by `Purgatory.mu`.
+	keys      []K             // the watched keys, protected by `Purgatory.mu`.
+	elems     []*list.Element // the elements in watchers of keys, protected by `Purgatory.mu`.
+}
+
+// Purgatory holds the delayed operations, each operation watches some keys,
+// and is checked for completion when an event happens on any of the keys.
+type Purgatory[K comparable] struct {
+	timer    *timer.Timer
+	mu       sync.Mutex // protects following fields.
+	watchers map[K]*list.List
+	watched  int // the number of watch entries.
+	delayed  int // the number of operations being watched.
+}
+
+// New new purgatory, the timeouts of the operations are scheduled on the timer t.
+func New[K comparable](t *timer.Timer) *Purgatory[K] {
+	return &Purgatory[K]{
+		timer:    t,
+		watchers: make(map[K]*list.List),
+	}
+}
+
+// TryCompleteElseWatch try to complete the operation, if it can not be completed,
+// watch it on all the keys and schedule its timeout.
+// It returns true if the operation is completed by this call.
+// NOTE: if the timer is closed, the operation is expired immediately.
+func (p *Purgatory[K]) TryCompleteElseWatch(op DelayedOperation, keys []K) bool {
+	o := &operation[K]{DelayedOperation: op}
+	o.task = timer.NewTaskFunc(op.Delay(), func() {
+		if p.forceComplete(o) {
+			o.OnExpiration()
+		}
+	}).WithNoSkip().WithNoSpreading()
+
+	if p.tryComplete(o) {
+		return true
+	}
+	if !p.watch(o, keys) {
+		return false
+	}
+	// events may happen on the keys before being watched, check again.
+	if p.tryComplete(o) {
+		return true
+	}
+	if err := p.timer.AddTask(o.task); err != nil {
+		o.task.Run()
+		return false
+	}
+	// completed before the task is added, the cancellation in forceComplete may miss it.
+	if o.completed.Load() {
+		o.task.Cancel()
+	}
+	return false
+}
+
+// CheckAndComplete checks the operations watching the key, and completes the ones can be completed.
+// It returns the number of operations completed by this call.
+func (p *Purgatory[K]) CheckAndComplete(key K) int {
+	p.mu.Lock()
+	l, ok := p.watchers[key]
+	if !ok {
+		p.mu.Unlock()
+		return 0
+	}
+	ops := make([]*operation[K], 0, l.Len())
+	for e := l.Front(); e != nil; e = e.Next() {
+		ops = append(ops, e.Value.(*operation[K]))
+	}
+	p.mu.Unlock()
+
+	completed := 0
+	for _, o := range ops {
+		if p.tryComplete(o) {
+			completed++
+		}
+	}
+	return completed
+}
+
+// Delayed returns the number of operations waiting for completion or expiration.
+func (p *Purgatory[K]) Delayed() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.delayed
+}
+
+// Watched returns the number of watch entries of all the keys,
+// an operation watching n keys counts n.
+func (p *Purgatory[K]) Watched() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.watched
+}
+
+func (p *Purgatory[K]) tryComplete(o *operation[K]) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return !o.completed.Load() && o.TryComplete() && p.forceComplete(o)
+}
+
+// forceComplete completes the operation if it has not been completed,
+// only one caller can complete it.
+func (p *Purgatory[K]) forceComplete(o *operation[K]) bool {
+	if !o.completed.CompareAndSwap(false, true) {
+		return false
+	}
+	o.task.Cancel()
+	p.unwatch(o)
+	o.OnComplete()
+	return true
+}
+
+// watch the operation on the keys, return false if it has been completed.
+func (p *Purgatory[K]) watch(o *operation[K], keys []K) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if o.completed.Load() {
+		return false
+	}
+	o.watched = true
+	o.keys = append(o.keys, keys...)
+	for _, key := range keys {
+		l, ok := p.watchers[key]
+		if !ok {
+			l = list.New()
+			p.watchers[key] = l
+		}
+		o.elems = append(o.elems, l.PushBack(o))
+	}
+	p.watched += len(keys)
+	p.delayed++
+	return true
+}
+
+// unwatch the operation on all its keys.
+func (p *Purgatory[K]) unwatch(o *operation[K]) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !o.watched {
+		return
+	}
+	for i, key := range o.keys {
+		if l, ok := p.watchers[key]; ok {
+			l.Remove(o.elems[i])
+			if l.Len() == 0 {
+				delete(p.watchers, key)
+			}
+		}
+	}
+	p.watched -= len(o.keys)
+	p.delayed--
+	o.watched, o.keys, o.elems = false, nil, nil
+}
@@ -0,0 +1,120 @@
+package purgatory
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thinkgos/timer"
+)
+
+// acksOperation completes when enough acks are received.
+type acksOperation struct {
+	delay    time.Duration
+	required int64
+	acks     *atomic.Int64
+
+	completed atomic.Int64
+	expired   atomic.Int64
+}
+
+func (op *acksOperation) Delay() time.Duration { return op.delay }
+func (op *acksOperation) TryComplete() bool    { return op.acks.Load() >= op.required }
+func (op *acksOperation) OnComplete()          { op.completed.Add(1) }
+func (op *acksOperation) OnExpiration()        { op.expired.Add(1) }
+
+func Test_Purgatory(t *testing.T) {
+	tm := timer.NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	p := New[string](tm)
+	acks := &atomic.Int64{}
+
+	t.Run("complete immediately", func(t *testing.T) {
+		acks.Store(1)
+		op := &acksOperation{delay: time.Second, required: 1, acks: acks}
+		require.True(t, p.TryCompleteElseWatch(op, []string{"a"}))
+		require.Equal(t, int64(1), op.completed.Load())
+		require.Zero(t, p.Delayed())
+		require.Zero(t, p.Watched())
+	})
+
+	t.Run("complete by check", func(t *testing.T) {
+		acks.Store(0)
+		op := &acksOperation{delay: time.Second, required: 2, acks: acks}
+		require.False(t, p.TryCompleteElseWatch(op, []string{"a", "b"}))
+		require.Equal(t, 1, p.Delayed())
+		require.Equal(t, 2, p.Watched())
+
+		acks.Add(1)
+		require.Zero(t, p.CheckAndComplete("a"))
+		acks.Add(1)
+		require.Equal(t, 1, p.CheckAndComplete("b"))
+		require.Zero(t, p.CheckAndComplete("a"))
+		require.Zero(t, p.Delayed())
+		require.Zero(t, p.Watched())
+
+		time.Sleep(20 * time.Millisecond)
+		require.Equal(t, int64(1), op.completed.Load())
+		require.Zero(t, op.expired.Load())
+	})
+
+	t.Run("expire", func(t *testing.T) {
+		acks.Store(0)
+		op := &acksOperation{delay: 20 * time.Millisecond, required: 1, acks: acks}
+		require.False(t, p.TryCompleteElseWatch(op, []string{"a"}))
+		require.Eventually(t, func() bool { return op.expired.Load() == 1 }, time.Second, time.Millisecond)
+		require.Equal(t, int64(1), op.completed.Load())
+		require.Zero(t, p.Delayed())
+		require.Zero(t, p.Watched())
+
+		acks.Store(1)
+		require.Zero(t, p.CheckAndComplete("a"))
+		require.Equal(t, int64(1), op.completed.Load())
+	})
+
+	t.Run("timer closed", func(t *testing.T) {
+		p := New[string](timer.NewTimer())
+		op := &acksOperation{delay: time.Second, required: 1, acks: &atomic.Int64{}}
+		require.False(t, p.TryCompleteElseWatch(op, []string{"a"}))
+		require.Equal(t, int64(1), op.completed.Load())
+		require.Equal(t, int64(1), op.expired.Load())
+		require.Zero(t, p.Delayed())
+	})
+}
+
+func Test_Purgatory_ExactlyOnce(t *testing.T) {
+	tm := timer.NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	p := New[int](tm)
+	acks := &atomic.Int64{}
+	ops := make([]*acksOperation, 1000)
+	for i := range ops {
+		// some expire, some complete by check.
+		ops[i] = &acksOperation{delay: time.Duration(i%20) * time.Millisecond, required: 1, acks: acks}
+		p.TryCompleteElseWatch(ops[i], []int{i % 10, i % 7})
+	}
+	acks.Store(1)
+	var wg sync.WaitGroup
+	for key := 0; key < 10; key++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.CheckAndComplete(key)
+		}()
+	}
+	wg.Wait()
+
+	require.Eventually(t, func() bool { return p.Delayed() == 0 }, time.Second, time.Millisecond)
+	require.Zero(t, p.Watched())
+	time.Sleep(30 * time.Millisecond)
+	for _, op := range ops {
+		require.Equal(t, int64(1), op.completed.Load())
+		require.LessOrEqual(t, op.expired.Load(), int64(1))
+	}
+}
@@ -0,0 +1,161 @@
+package queue
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/thinkgos/timer/comparator"
+)
+
+// ConcurrentPriorityQueue represents an unbounded priority queue which is safe for concurrent use.
+// It wraps PriorityQueue with a mutex, and supports blocking retrieval.
+type ConcurrentPriorityQueue[T comparable] struct {
+	notify  chan struct{}     // notify channel, wake up one of the waiters.
+	mu      sync.Mutex        // protects following fields
+	pq      *PriorityQueue[T] // priority queue
+	waiters int               // the number of waiters blocking on PopWait.
+}
+
+// NewConcurrentPriorityQueue initializes and returns a concurrent priority Queue, default min heap.
+func NewConcurrentPriorityQueue[T cmp.Ordered](opts ...Option[T]) *ConcurrentPriorityQueue[T] {
+	return NewConcurrentPriorityQueueWith(cmp.Compare[T], opts...)
+}
+
+// NewConcurrentPriorityQueueWith initializes and returns a concurrent priority Queue, default min heap.
+func NewConcurrentPriorityQueueWith[T comparable](cmp comparator.Comparable[T], opts ...Option[T]) *ConcurrentPriorityQueue[T] {
+	return &ConcurrentPriorityQueue[T]{
+		notify: make(chan struct{}, 1),
+		pq:     NewPriorityQueueWith(cmp, opts...),
+	}
+}
+
+// Len returns the length of this priority queue.
+func (q *ConcurrentPriorityQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Len()
+}
+
+// IsEmpty returns true if this list contains no elements.
+func (q *ConcurrentPriorityQueue[T]) IsEmpty() bool { return q.Len() == 0 }
+
+// Clear removes all the elements from this priority queue.
+func (q *ConcurrentPriorityQueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pq.Clear()
+}
+
+// Push inserts the specified element into this priority queue.
+func (q *ConcurrentPriorityQueue[T]) Push(item T) {
+	q.mu.Lock()
+	q.pq.Push(item)
+	wakeUp := q.waiters > 0
+	q.mu.Unlock()
+	if wakeUp {
+		q.wakeUp()
+	}
+}
+
+// PushAll inserts all the specified elements into this priority queue with a single lock acquisition.
+func (q *ConcurrentPriorityQueue[T]) PushAll(items ...T) {
+	if len(items) == 0 {
+		return
+	}
+	q.mu.Lock()
+	for _, item := range items {
+		q.pq.Push(item)
+	}
+	wakeUp := q.waiters > 0
+	q.mu.Unlock()
+	if wakeUp {
+		q.wakeUp()
+	}
+}
+
+// Peek retrieves, but does not remove, the head of this queue, or return false if this queue is empty.
+func (q *ConcurrentPriorityQueue[T]) Peek() (val T, exist bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Peek()
+}
+
+// TryPop retrieves and removes the head of the this queue without blocking,
+// or return false if this queue is empty.
+func (q *ConcurrentPriorityQueue[T]) TryPop() (val T, exist bool) {
+	q.mu.Lock()
+	val, exist = q.pq.Pop()
+	wakeUp := exist && q.waiters > 0 && q.pq.Len() > 0
+	q.mu.Unlock()
+	if wakeUp {
+		q.wakeUp()
+	}
+	return val, exist
+}
+
+// PopN retrieves and removes at most n elements from the head of this queue without blocking,
+// the elements are in priority order.
+func (q *ConcurrentPriorityQueue[T]) PopN(n int) []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n = min(n, q.pq.Len())
+	if n <= 0 {
+		return nil
+	}
+	items := make([]T, 0, n)
+	for ; n > 0; n-- {
+		val, _ := q.pq.Pop()
+		items = append(items, val)
+	}
+	return items
+}
+
+// PopWait retrieves and removes the head of the this queue,
+// waiting if necessary until an element becomes available or the context is done.
+func (q *ConcurrentPriorityQueue[T]) PopWait(ctx context.Context) (val T, err error) {
+	waiting := false
+	for {
+		q.mu.Lock()
+		if waiting {
+			q.waiters--
+		}
+		val, exist := q.pq.Pop()
+		if exist {
+			// pass the baton, there are remaining elements for other waiters.
+			wakeUp := q.waiters > 0 && q.pq.Len() > 0
+			q.mu.Unlock()
+			if wakeUp {
+				q.wakeUp()
+			}
+			return val, nil
+		}
+		q.waiters++
+		waiting = true
+		q.mu.Unlock()
+
+		select {
+		case <-q.notify:
+		case <-ctx.Done():
+			q.mu.Lock()
+			q.waiters--
+			q.mu.Unlock()
+			return val, ctx.Err()
+		}
+	}
+}
+
+// Snapshot returns a sorted copy of the elements in this priority queue, the queue is unchanged.
+func (q *ConcurrentPriorityQueue[T]) Snapshot() []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return slices.Collect(q.pq.Sorted())
+}
+
+func (q *ConcurrentPriorityQueue[T]) wakeUp() {
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
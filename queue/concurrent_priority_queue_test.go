@@ -0,0 +1,164 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConcurrentPriorityQueue_Value(t *testing.T) {
+	q := NewConcurrentPriorityQueue[int]()
+	require.True(t, q.IsEmpty())
+
+	val, ok := q.TryPop()
+	require.False(t, ok)
+	require.Zero(t, val)
+
+	q.Push(15)
+	q.PushAll(19, 12, 8, 13)
+	q.PushAll()
+	require.Equal(t, 5, q.Len())
+
+	val, ok = q.Peek()
+	require.True(t, ok)
+	require.Equal(t, 8, val)
+
+	require.Equal(t, []int{8, 12, 13, 15, 19}, q.Snapshot())
+	require.Equal(t, 5, q.Len())
+
+	val, ok = q.TryPop()
+	require.True(t, ok)
+	require.Equal(t, 8, val)
+
+	require.Equal(t, []int{12, 13}, q.PopN(2))
+	require.Equal(t, []int{15, 19}, q.PopN(10))
+	require.Nil(t, q.PopN(1))
+
+	q.PushAll(1, 2, 3)
+	q.Clear()
+	require.Zero(t, q.Len())
+}
+
+func Test_ConcurrentPriorityQueue_Snapshot(t *testing.T) {
+	for name, opts := range map[string][]Option[int]{
+		"binary":      nil,
+		"binary max":  {WithMaxHeap[int]()},
+		"4-ary":       {WithDAryHeap[int](4)},
+		"pairing":     {WithPairingHeap[int]()},
+		"pairing max": {WithPairingHeap[int](), WithMaxHeap[int]()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			q := NewConcurrentPriorityQueue(opts...)
+			q.PushAll(5, 9, 3, 7, 10, 2, 6, 1, 8)
+			snapshot := q.Snapshot()
+			require.Len(t, snapshot, 9)
+			require.Equal(t, snapshot, q.PopN(q.Len()))
+		})
+	}
+}
+
+func Test_ConcurrentPriorityQueue_PopWait(t *testing.T) {
+	q := NewConcurrentPriorityQueue[int]()
+
+	// context done.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := q.PopWait(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// element available later.
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		q.Push(1)
+	}()
+	val, err := q.PopWait(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, val)
+}
+
+func Test_ConcurrentPriorityQueue_ProducerConsumer(t *testing.T) {
+	const (
+		producers = 8
+		consumers = 8
+		perWorker = 2000
+		total     = producers * perWorker
+	)
+
+	q := NewConcurrentPriorityQueue[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		consumed atomic.Int64
+		seen     sync.Map
+		wg       sync.WaitGroup
+	)
+	for i := 0; i < consumers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for {
+				var vals []int
+				switch i % 3 {
+				case 0:
+					val, err := q.PopWait(ctx)
+					if err != nil {
+						return
+					}
+					vals = []int{val}
+				case 1:
+					if val, ok := q.TryPop(); ok {
+						vals = []int{val}
+					}
+				default:
+					vals = q.PopN(4)
+				}
+				for _, val := range vals {
+					_, loaded := seen.LoadOrStore(val, struct{}{})
+					assert.False(t, loaded, "value %d consumed twice", val)
+				}
+				if consumed.Add(int64(len(vals))) >= total {
+					cancel()
+				}
+				if ctx.Err() != nil {
+					return
+				}
+			}
+		}(i)
+	}
+
+	var producerWg sync.WaitGroup
+	for i := 0; i < producers; i++ {
+		producerWg.Add(1)
+		go func(i int) {
+			defer producerWg.Done()
+			for j := 0; j < perWorker; j += 2 {
+				v := i*perWorker + j
+				if j%4 == 0 {
+					q.PushAll(v, v+1)
+				} else {
+					q.Push(v)
+					q.Push(v + 1)
+				}
+				if j%100 == 0 {
+					_ = q.Snapshot()
+				}
+			}
+		}(i)
+	}
+	producerWg.Wait()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(10 * time.Second):
+		t.Fatal("consumers did not drain the queue")
+	}
+	wg.Wait()
+	require.Equal(t, int64(total), consumed.Load())
+	require.Zero(t, q.Len())
+}
@@ -0,0 +1,106 @@
+package queue
+
+import (
+	"slices"
+
+	"github.com/thinkgos/timer/comparator"
+	"github.com/thinkgos/timer/go/heap"
+)
+
+// backingHeap the heap backing the PriorityQueue.
+type backingHeap[T any] interface {
+	Len() int
+	Push(T)
+	Pop() T
+	Peek() T
+	Clear()
+	Range(f func(T) bool)                              // iterate all elements in no particular order.
+	CloneTo(c *comparator.Container[T]) backingHeap[T] // clone the heap, c is the container of the cloned heap if needed.
+}
+
+var (
+	_ backingHeap[int] = (*binaryHeap[int])(nil)
+	_ backingHeap[int] = (*daryHeap[int])(nil)
+	_ backingHeap[int] = (*pairingHeap[int])(nil)
+)
+
+// binaryHeap binary heap based on comparator.Container.
+type binaryHeap[T any] struct {
+	container *comparator.Container[T]
+}
+
+func newBinaryHeap[T any](c *comparator.Container[T]) *binaryHeap[T] {
+	heap.Init(c)
+	return &binaryHeap[T]{container: c}
+}
+
+func (h *binaryHeap[T]) Len() int             { return h.container.Len() }
+func (h *binaryHeap[T]) Push(x T)             { heap.Push(h.container, x) }
+func (h *binaryHeap[T]) Pop() T               { return heap.Pop(h.container) }
+func (h *binaryHeap[T]) Peek() T              { return h.container.Items[0] }
+func (h *binaryHeap[T]) Clear()               { h.container.Items = make([]T, 0) }
+func (h *binaryHeap[T]) Range(f func(T) bool) { rangeItems(h.container.Items, f) }
+func (h *binaryHeap[T]) CloneTo(c *comparator.Container[T]) backingHeap[T] {
+	c.Items = slices.Clone(h.container.Items)
+	return &binaryHeap[T]{container: c}
+}
+
+// daryHeap d-ary heap based on comparator.Container.
+type daryHeap[T any] struct {
+	container *comparator.Container[T]
+	dary      heap.DAry[T]
+}
+
+func newDaryHeap[T any](c *comparator.Container[T], d int) *daryHeap[T] {
+	h := &daryHeap[T]{
+		container: c,
+		dary:      heap.NewDAry[T](d),
+	}
+	h.dary.Init(c)
+	return h
+}
+
+func (h *daryHeap[T]) Len() int             { return h.container.Len() }
+func (h *daryHeap[T]) Push(x T)             { h.dary.Push(h.container, x) }
+func (h *daryHeap[T]) Pop() T               { return h.dary.Pop(h.container) }
+func (h *daryHeap[T]) Peek() T              { return h.container.Items[0] }
+func (h *daryHeap[T]) Clear()               { h.container.Items = make([]T, 0) }
+func (h *daryHeap[T]) Range(f func(T) bool) { rangeItems(h.container.Items, f) }
+func (h *daryHeap[T]) CloneTo(c *comparator.Container[T]) backingHeap[T] {
+	c.Items = slices.Clone(h.container.Items)
+	return &daryHeap[T]{container: c, dary: h.dary}
+}
+
+// pairingHeap pairing heap, the items of comparator.Container are moved into the heap.
+type pairingHeap[T any] struct {
+	*heap.Pairing[T]
+}
+
+func newPairingHeap[T any](c *comparator.Container[T]) *pairingHeap[T] {
+	desc, compare := c.Desc, c.Compare
+	h := &pairingHeap[T]{
+		Pairing: heap.NewPairing(func(a, b T) bool {
+			if desc {
+				a, b = b, a
+			}
+			return compare(a, b) < 0
+		}),
+	}
+	for _, v := range c.Items {
+		h.Push(v)
+	}
+	c.Items = nil
+	return h
+}
+
+func (h *pairingHeap[T]) CloneTo(*comparator.Container[T]) backingHeap[T] {
+	return &pairingHeap[T]{Pairing: h.Pairing.Clone()}
+}
+
+func rangeItems[T any](items []T, f func(T) bool) {
+	for _, v := range items {
+		if !f(v) {
+			return
+		}
+	}
+}
@@ -2,15 +2,18 @@ package queue
 
 import (
 	"cmp"
+	"iter"
 
 	"github.com/thinkgos/timer/comparator"
-	"github.com/thinkgos/timer/go/heap"
 )
 
 // PriorityQueue represents an unbounded priority queue based on a priority heap.
 // It implements heap.Interface.
 type PriorityQueue[T comparable] struct {
 	container *comparator.Container[T]
+	arity     int  // arity of d-ary heap, default 2 is the binary heap.
+	pairing   bool // use pairing heap or not.
+	heap      backingHeap[T]
 }
 
 // Option customize the PriorityQueue
@@ -37,20 +40,25 @@ func WithItems[T comparable](items ...T) Option[T] {
 	}
 }
 
-// NewPriorityQueue initializes and returns a priority Queue, default min heap.
-func NewPriorityQueue[T cmp.Ordered](opts ...Option[T]) *PriorityQueue[T] {
-	pq := &PriorityQueue[T]{
-		container: &comparator.Container[T]{
-			Items:   []T{},
-			Desc:    false,
-			Compare: cmp.Compare[T],
-		},
+// WithDAryHeap customize the backing heap use d-ary heap, d must be greater than or equal to 2.
+// default is the binary heap(d = 2), a 4-ary heap has better cache behavior for large queue.
+func WithDAryHeap[T comparable](d int) Option[T] {
+	return func(pq *PriorityQueue[T]) {
+		pq.arity = d
+		pq.pairing = false
 	}
-	for _, f := range opts {
-		f(pq)
+}
+
+// WithPairingHeap customize the backing heap use pairing heap, which Push is O(1).
+func WithPairingHeap[T comparable]() Option[T] {
+	return func(pq *PriorityQueue[T]) {
+		pq.pairing = true
 	}
-	heap.Init(pq.container)
-	return pq
+}
+
+// NewPriorityQueue initializes and returns a priority Queue, default min heap.
+func NewPriorityQueue[T cmp.Ordered](opts ...Option[T]) *PriorityQueue[T] {
+	return NewPriorityQueueWith(cmp.Compare[T], opts...)
 }
 
 // NewPriorityQueueWith initializes and returns a priority Queue, default min heap.
@@ -61,30 +69,39 @@ func NewPriorityQueueWith[T comparable](cmp comparator.Comparable[T], opts ...Op
 			Desc:    false,
 			Compare: cmp,
 		},
+		arity:   2,
+		pairing: false,
 	}
 	for _, f := range opts {
 		f(pq)
 	}
-	heap.Init(pq.container)
+	switch {
+	case pq.pairing:
+		pq.heap = newPairingHeap(pq.container)
+	case pq.arity == 2:
+		pq.heap = newBinaryHeap(pq.container)
+	default:
+		pq.heap = newDaryHeap(pq.container, pq.arity)
+	}
 	return pq
 }
 
 // Len returns the length of this priority queue.
-func (pq *PriorityQueue[T]) Len() int { return pq.container.Len() }
+func (pq *PriorityQueue[T]) Len() int { return pq.heap.Len() }
 
 // IsEmpty returns true if this list contains no elements.
 func (pq *PriorityQueue[T]) IsEmpty() bool { return pq.Len() == 0 }
 
 // Clear removes all the elements from this priority queue.
-func (pq *PriorityQueue[T]) Clear() { pq.container.Items = make([]T, 0) }
+func (pq *PriorityQueue[T]) Clear() { pq.heap.Clear() }
 
 // Push inserts the specified element into this priority queue.
-func (pq *PriorityQueue[T]) Push(item T) { heap.Push(pq.container, item) }
+func (pq *PriorityQueue[T]) Push(item T) { pq.heap.Push(item) }
 
 // Peek retrieves, but does not remove, the head of this queue, or return nil if this queue is empty.
 func (pq *PriorityQueue[T]) Peek() (val T, exist bool) {
 	if pq.Len() > 0 {
-		return pq.container.Items[0], true
+		return pq.heap.Peek(), true
 	}
 	return val, false
 }
@@ -92,7 +109,44 @@ func (pq *PriorityQueue[T]) Peek() (val T, exist bool) {
 // Pop retrieves and removes the head of the this queue, or return nil if this queue is empty.
 func (pq *PriorityQueue[T]) Pop() (val T, exist bool) {
 	if pq.Len() > 0 {
-		return heap.Pop(pq.container), true
+		return pq.heap.Pop(), true
 	}
 	return val, false
 }
+
+// All returns an iterator over the elements in this priority queue, in no particular order.
+// It is zero-copy, the queue must not be modified during the iteration.
+func (pq *PriorityQueue[T]) All() iter.Seq[T] {
+	return pq.heap.Range
+}
+
+// Sorted returns an iterator over the elements in this priority queue, in priority order.
+// It iterates over a clone taken when the iteration starts, the queue is unchanged.
+// The clone costs O(n) when the iteration starts, then each element iterated costs O(log n),
+// so stopping early after k elements costs O(n + k*log n).
+func (pq *PriorityQueue[T]) Sorted() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		c := pq.Clone()
+		for v, ok := c.Pop(); ok; v, ok = c.Pop() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns a copy of this priority queue with the same length and options,
+// the elements are shallow copied.
+func (pq *PriorityQueue[T]) Clone() *PriorityQueue[T] {
+	c := &PriorityQueue[T]{
+		container: &comparator.Container[T]{
+			Items:   []T{},
+			Desc:    pq.container.Desc,
+			Compare: pq.container.Compare,
+		},
+		arity:   pq.arity,
+		pairing: pq.pairing,
+	}
+	c.heap = pq.heap.CloneTo(c.container)
+	return c
+}
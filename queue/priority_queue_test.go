@@ -2,6 +2,8 @@ package queue
 
 import (
 	"cmp"
+	"math/rand/v2"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -111,3 +113,134 @@ func pq_Test_PriorityQueue_DeleteImpl[T comparable](t *testing.T, q *PriorityQue
 	}
 	require.Zero(t, q.Len())
 }
+
+func Test_PriorityQueue_BackingHeap(t *testing.T) {
+	input := []int{15, 19, 12, 8, 13, 1, 25, 8, 3}
+	wantMin := []int{1, 3, 8, 8, 12, 13, 15, 19, 25}
+	wantMax := []int{25, 19, 15, 13, 12, 8, 8, 3, 1}
+
+	for name, opt := range map[string]Option[int]{
+		"3-ary":   WithDAryHeap[int](3),
+		"4-ary":   WithDAryHeap[int](4),
+		"pairing": WithPairingHeap[int](),
+	} {
+		t.Run(name, func(t *testing.T) {
+			pq_Test_PriorityQueue_SortImpl(t, NewPriorityQueue(opt), input, wantMin)
+			pq_Test_PriorityQueue_SortImpl(t, NewPriorityQueue(opt, WithMaxHeap[int]()), input, wantMax)
+
+			// init items
+			q := NewPriorityQueue(opt, WithItems(15, 19, 12, 8, 13))
+			require.Equal(t, 5, q.Len())
+			val, ok := q.Peek()
+			require.True(t, ok)
+			require.Equal(t, 8, val)
+			val, ok = q.Pop()
+			require.True(t, ok)
+			require.Equal(t, 8, val)
+			require.Equal(t, 4, q.Len())
+
+			// Clear
+			q.Clear()
+			require.True(t, q.IsEmpty())
+			_, ok = q.Peek()
+			require.False(t, ok)
+			_, ok = q.Pop()
+			require.False(t, ok)
+		})
+	}
+
+	require.Panics(t, func() {
+		_ = NewPriorityQueue(WithDAryHeap[int](1))
+	})
+}
+
+func Benchmark_PriorityQueue(b *testing.B) {
+	const n = 100000
+
+	input := make([]int64, n)
+	for i := range input {
+		input[i] = rand.Int64N(n)
+	}
+	for _, bb := range []struct {
+		name string
+		opts []Option[int64]
+	}{
+		{"binary", nil},
+		{"4-ary", []Option[int64]{WithDAryHeap[int64](4)}},
+		{"pairing", []Option[int64]{WithPairingHeap[int64]()}},
+	} {
+		b.Run(bb.name+"/push-pop", func(b *testing.B) {
+			pq := NewPriorityQueue(bb.opts...)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, v := range input {
+					pq.Push(v)
+				}
+				for !pq.IsEmpty() {
+					pq.Pop()
+				}
+			}
+		})
+		b.Run(bb.name+"/push", func(b *testing.B) {
+			pq := NewPriorityQueue(bb.opts...)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				pq.Push(input[i%n])
+			}
+		})
+	}
+}
+
+func Test_PriorityQueue_Iterator(t *testing.T) {
+	input := []int{15, 19, 12, 8, 13, 1, 25, 8, 3}
+	wantMin := []int{1, 3, 8, 8, 12, 13, 15, 19, 25}
+
+	for name, opts := range map[string][]Option[int]{
+		"binary":  nil,
+		"4-ary":   {WithDAryHeap[int](4)},
+		"pairing": {WithPairingHeap[int]()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			q := NewPriorityQueue(append(opts, WithItems(slices.Clone(input)...))...)
+
+			// All, unordered
+			all := slices.Collect(q.All())
+			slices.Sort(all)
+			require.Equal(t, wantMin, all)
+			require.Equal(t, len(input), q.Len())
+
+			// Sorted, non-destructive
+			require.Equal(t, wantMin, slices.Collect(q.Sorted()))
+			require.Equal(t, len(input), q.Len())
+			for v := range q.Sorted() {
+				require.Equal(t, wantMin[0], v)
+				break
+			}
+
+			// Clone
+			c := q.Clone()
+			require.Equal(t, q.Len(), c.Len())
+			c.Push(0)
+			require.Equal(t, len(input), q.Len())
+			val, _ := q.Peek()
+			require.Equal(t, 1, val)
+			val, _ = c.Pop()
+			require.Equal(t, 0, val)
+			for _, want := range wantMin {
+				val, _ = c.Pop()
+				require.Equal(t, want, val)
+			}
+			require.Zero(t, c.Len())
+			require.Equal(t, len(input), q.Len())
+
+			// max heap
+			q = NewPriorityQueue(append(opts, WithMaxHeap[int](), WithItems(slices.Clone(input)...))...)
+			wantMax := slices.Clone(wantMin)
+			slices.Reverse(wantMax)
+			require.Equal(t, wantMax, slices.Collect(q.Sorted()))
+			require.Equal(t, wantMax, slices.Collect(q.Clone().Sorted()))
+		})
+	}
+}
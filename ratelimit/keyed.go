@@ -0,0 +1,106 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/thinkgos/timer"
+)
+
+// KeyedOption customize the Keyed.
+type KeyedOption func(*keyedConfig)
+
+type keyedConfig struct {
+	purgeInterval time.Duration
+}
+
+// WithPurgeInterval the interval to drop the idle limiters, default 1 minute.
+func WithPurgeInterval(d time.Duration) KeyedOption {
+	return func(c *keyedConfig) { c.purgeInterval = d }
+}
+
+// Keyed limits per key, such as per tenant, the limiter of a key is created on demand,
+// and dropped when it is idle, the purge task is only on the timer while there are limiters.
+type Keyed[K comparable] struct {
+	timer      *timer.Timer
+	newLimiter func() Limiter
+	purge      *timer.Task
+	mu         sync.Mutex // protects following fields.
+	limiters   map[K]Limiter
+	scheduled  bool // the purge task is scheduled.
+}
+
+// NewKeyed new keyed limiter, newLimiter creates the limiter of a key,
+// the limiters should be on the same timer t.
+func NewKeyed[K comparable](t *timer.Timer, newLimiter func() Limiter, opts ...KeyedOption) *Keyed[K] {
+	c := keyedConfig{
+		purgeInterval: time.Minute,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	k := &Keyed[K]{
+		timer:      t,
+		newLimiter: newLimiter,
+		limiters:   make(map[K]Limiter),
+	}
+	k.purge = timer.NewTaskFunc(c.purgeInterval, k.onPurge).WithNoSkip().WithNoSpreading()
+	return k
+}
+
+// Allow reports whether an event of the key may happen now.
+func (k *Keyed[K]) Allow(key K) bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.limiter(key).Allow()
+}
+
+// Reserve a permit of an event of the key in the future.
+func (k *Keyed[K]) Reserve(key K) *Reservation {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.limiter(key).Reserve()
+}
+
+// Wait blocks until an event of the key may happen.
+func (k *Keyed[K]) Wait(ctx context.Context, key K) error {
+	// NOTE: wait without lock, the limiter holding the reservation is not idle, so it is not dropped.
+	return wait(ctx, k.timer, k.Reserve(key))
+}
+
+// Len returns the number of the limiters.
+func (k *Keyed[K]) Len() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return len(k.limiters)
+}
+
+// limiter returns the limiter of the key, creates it if not exist.
+// NOTE: should be call when `Keyed.mu` lock, and take the permit before unlock,
+// otherwise the limiter may be dropped as idle by the purge.
+func (k *Keyed[K]) limiter(key K) Limiter {
+	l, ok := k.limiters[key]
+	if !ok {
+		l = k.newLimiter()
+		k.limiters[key] = l
+		if !k.scheduled && k.timer.AddTask(k.purge) == nil {
+			k.scheduled = true
+		}
+	}
+	return l
+}
+
+func (k *Keyed[K]) onPurge() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.scheduled = false
+	for key, l := range k.limiters {
+		if l.idle() {
+			delete(k.limiters, key)
+		}
+	}
+	if len(k.limiters) > 0 && k.timer.AddTask(k.purge) == nil {
+		k.scheduled = true
+	}
+}
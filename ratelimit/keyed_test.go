@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thinkgos/timer"
+)
+
+func Test_Keyed(t *testing.T) {
+	tm := timer.NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	k := NewKeyed[string](tm, func() Limiter {
+		return NewTokenBucket(tm, 20*time.Millisecond, 1)
+	}, WithPurgeInterval(10*time.Millisecond))
+
+	require.True(t, k.Allow("a"))
+	require.False(t, k.Allow("a"))
+	require.True(t, k.Allow("b"))
+	require.True(t, k.Reserve("c").OK())
+	require.Equal(t, 3, k.Len())
+	require.NoError(t, k.Wait(context.Background(), "a"))
+
+	// the idle limiters are dropped.
+	require.Eventually(t, func() bool { return k.Len() == 0 }, time.Second, time.Millisecond)
+	require.True(t, k.Allow("a"))
+	require.Equal(t, 1, k.Len())
+}
+
+func Test_Keyed_Purge_Race(t *testing.T) {
+	tm := timer.NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	k := NewKeyed[int](tm, func() Limiter {
+		return NewTokenBucket(tm, time.Hour, 1)
+	}, WithPurgeInterval(time.Millisecond))
+
+	// the permit is taken before the purge can drop the limiter, only one event of a key is allowed.
+	for key := range 200 {
+		var allowed atomic.Int64
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 10 {
+					if k.Allow(key) {
+						allowed.Add(1)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		require.Equal(t, int64(1), allowed.Load())
+	}
+}
@@ -0,0 +1,89 @@
+// Package ratelimit provides token-bucket and sliding-window rate limiters,
+// whose refills and window rollovers are scheduled on a shared timer.Timer
+// instead of per-limiter goroutines or tickers.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thinkgos/timer"
+)
+
+// ErrWouldExceedDeadline is returned by Wait when the wait would exceed the context deadline.
+var ErrWouldExceedDeadline = errors.New("ratelimit: wait would exceed context deadline")
+
+// Limiter a rate limiter.
+type Limiter interface {
+	// Allow reports whether an event may happen now.
+	Allow() bool
+	// Reserve a permit of an event in the future.
+	Reserve() *Reservation
+	// Wait blocks until an event may happen, the waiter is parked as a timer task.
+	Wait(ctx context.Context) error
+
+	// idle reports whether the limiter is at its initial state, and can be dropped.
+	idle() bool
+}
+
+// Reservation holds a permit of an event that may happen after a delay.
+type Reservation struct {
+	err       error     // the error why the reservation can not be made.
+	timeToAct time.Time // the time the event may happen.
+	cancel    func()    // return the permit.
+}
+
+// OK reports whether the reservation is made.
+// if false, Delay returns 0 and Cancel does nothing.
+func (r *Reservation) OK() bool { return r.err == nil }
+
+// Delay returns the duration to wait before the event may happen, 0 means now.
+func (r *Reservation) Delay() time.Duration {
+	if !r.OK() {
+		return 0
+	}
+	return max(time.Until(r.timeToAct), 0)
+}
+
+// Cancel the reservation, return the permit if the event has not happened yet.
+func (r *Reservation) Cancel() {
+	if r.OK() && r.cancel != nil && time.Now().Before(r.timeToAct) {
+		r.cancel()
+		r.cancel = nil
+	}
+}
+
+// wait parks the waiter as a timer task until the reservation may act.
+func wait(ctx context.Context, t *timer.Timer, r *Reservation) error {
+	if err := ctx.Err(); err != nil {
+		r.Cancel()
+		return err
+	}
+	if !r.OK() {
+		return r.err
+	}
+	delay := r.Delay()
+	if delay == 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(r.timeToAct) {
+		r.Cancel()
+		return ErrWouldExceedDeadline
+	}
+
+	ready := make(chan struct{})
+	task := timer.NewTaskFunc(delay, func() { close(ready) }).WithNoSkip().WithNoSpreading()
+	if err := t.AddTask(task); err != nil {
+		r.Cancel()
+		return err
+	}
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		task.Cancel()
+		r.Cancel()
+		return ctx.Err()
+	}
+}
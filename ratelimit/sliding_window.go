@@ -0,0 +1,143 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/thinkgos/timer"
+)
+
+var _ Limiter = (*SlidingWindow)(nil)
+
+// SlidingWindow a sliding-window limiter, which allows at most limit events in any window,
+// it estimates the events of the sliding window by weighting the previous fixed window.
+// The rollover task drops the stale windows, and is only on the timer while there are events counted.
+type SlidingWindow struct {
+	timer     *timer.Timer
+	windowMs  int64
+	limit     int
+	rollover  *timer.Task
+	mu        sync.Mutex    // protects following fields.
+	counts    map[int64]int // the events of the fixed windows, key is the index of the window.
+	latest    int64         // the latest window index with events, include the reserved.
+	scheduled bool          // the rollover task is scheduled.
+}
+
+// NewSlidingWindow new sliding-window limiter, the accuracy of window is milliseconds.
+// window and limit must be greater than zero; if not, NewSlidingWindow will panic.
+func NewSlidingWindow(t *timer.Timer, window time.Duration, limit int) *SlidingWindow {
+	if window.Milliseconds() <= 0 || limit <= 0 {
+		panic("ratelimit: non-positive window or limit for NewSlidingWindow")
+	}
+	sw := &SlidingWindow{
+		timer:    t,
+		windowMs: window.Milliseconds(),
+		limit:    limit,
+		counts:   make(map[int64]int),
+	}
+	sw.rollover = timer.NewTaskFunc(window, sw.onRollover).WithNoSkip().WithNoSpreading()
+	return sw
+}
+
+// Allow implements Limiter.
+// It returns false if there are waiters reserved, to keep them in order.
+func (sw *SlidingWindow) Allow() bool {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	nowMs := time.Now().UnixMilli()
+	idx := nowMs / sw.windowMs
+	if sw.latest > idx {
+		return false
+	}
+	if atMs, ok := sw.earliest(idx, nowMs); !ok || atMs > nowMs {
+		return false
+	}
+	sw.take(idx)
+	return true
+}
+
+// Reserve implements Limiter.
+func (sw *SlidingWindow) Reserve() *Reservation {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	nowMs := time.Now().UnixMilli()
+	idx := max(nowMs/sw.windowMs, sw.latest)
+	for {
+		if atMs, ok := sw.earliest(idx, nowMs); ok {
+			sw.take(idx)
+			return &Reservation{
+				timeToAct: time.UnixMilli(atMs),
+				cancel:    func() { sw.giveBack(idx) },
+			}
+		}
+		idx++
+	}
+}
+
+// Wait implements Limiter.
+func (sw *SlidingWindow) Wait(ctx context.Context) error {
+	return wait(ctx, sw.timer, sw.Reserve())
+}
+
+func (sw *SlidingWindow) idle() bool {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	return len(sw.counts) == 0
+}
+
+// earliest returns the earliest time in the window idx, not before nowMs, an event may happen.
+// NOTE: should be call when `SlidingWindow.mu` lock.
+func (sw *SlidingWindow) earliest(idx, nowMs int64) (int64, bool) {
+	prev, curr := sw.counts[idx-1], sw.counts[idx]
+	if curr >= sw.limit {
+		return 0, false
+	}
+	startMs := idx * sw.windowMs
+	atMs := startMs
+	if prev > 0 {
+		// prev * (1 - elapsed/window) + curr + 1 <= limit
+		need := sw.windowMs - sw.windowMs*int64(sw.limit-curr-1)/int64(prev)
+		atMs = startMs + max(need, 0)
+	}
+	atMs = max(atMs, nowMs)
+	return atMs, atMs < startMs+sw.windowMs
+}
+
+// NOTE: should be call when `SlidingWindow.mu` lock.
+func (sw *SlidingWindow) take(idx int64) {
+	sw.counts[idx]++
+	sw.latest = max(sw.latest, idx)
+	if !sw.scheduled && sw.timer.AddTask(sw.rollover) == nil {
+		sw.scheduled = true
+	}
+}
+
+func (sw *SlidingWindow) giveBack(idx int64) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if sw.counts[idx] > 0 {
+		sw.counts[idx]--
+	}
+}
+
+func (sw *SlidingWindow) onRollover() {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	sw.scheduled = false
+	nowMs := time.Now().UnixMilli()
+	idx := nowMs / sw.windowMs
+	for i, n := range sw.counts {
+		// the previous window is still needed by the estimation.
+		if i < idx-1 || n == 0 && i < idx {
+			delete(sw.counts, i)
+		}
+	}
+	if len(sw.counts) == 0 {
+		return
+	}
+	delay := time.Duration((idx+1)*sw.windowMs-nowMs) * time.Millisecond
+	if sw.timer.AddTask(sw.rollover.SetDelay(delay)) == nil {
+		sw.scheduled = true
+	}
+}
@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thinkgos/timer"
+)
+
+func Test_SlidingWindow(t *testing.T) {
+	tm := timer.NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	require.Panics(t, func() { NewSlidingWindow(tm, 0, 1) })
+	require.Panics(t, func() { NewSlidingWindow(tm, time.Second, 0) })
+
+	sw := NewSlidingWindow(tm, 50*time.Millisecond, 5)
+	require.True(t, sw.idle())
+	allowed := 0
+	start := time.Now()
+	for time.Since(start) < 200*time.Millisecond {
+		if sw.Allow() {
+			allowed++
+		}
+		time.Sleep(time.Millisecond)
+	}
+	// about limit per window.
+	require.GreaterOrEqual(t, allowed, 15)
+	require.LessOrEqual(t, allowed, 25)
+
+	// the rollover drops the stale windows.
+	require.Eventually(t, sw.idle, time.Second, time.Millisecond)
+}
+
+func Test_SlidingWindow_Reserve(t *testing.T) {
+	tm := timer.NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	sw := NewSlidingWindow(tm, 50*time.Millisecond, 2)
+	rs := make([]*Reservation, 6)
+	for i := range rs {
+		rs[i] = sw.Reserve()
+		require.True(t, rs[i].OK())
+	}
+	require.Zero(t, rs[0].Delay())
+	require.Zero(t, rs[1].Delay())
+	for i := 2; i < len(rs); i++ {
+		require.GreaterOrEqual(t, rs[i].Delay(), rs[i-1].Delay())
+	}
+	require.Greater(t, rs[2].Delay(), time.Duration(0))
+	require.GreaterOrEqual(t, rs[5].Delay(), 50*time.Millisecond)
+	// waiters are reserved, keep them in order.
+	require.False(t, sw.Allow())
+
+	for _, r := range rs {
+		r.Cancel()
+	}
+	require.Eventually(t, sw.idle, time.Second, time.Millisecond)
+}
+
+func Test_SlidingWindow_Wait(t *testing.T) {
+	tm := timer.NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	sw := NewSlidingWindow(tm, 30*time.Millisecond, 2)
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		require.NoError(t, sw.Wait(context.Background()))
+	}
+	require.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, sw.Wait(ctx), context.Canceled)
+}
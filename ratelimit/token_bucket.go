@@ -0,0 +1,116 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/thinkgos/timer"
+)
+
+var _ Limiter = (*TokenBucket)(nil)
+
+// TokenBucket a token-bucket limiter, which holds at most burst tokens and refills one token every interval.
+// The refill task is only on the timer while the bucket is not full.
+type TokenBucket struct {
+	timer      *timer.Timer
+	every      time.Duration
+	burst      int
+	refill     *timer.Task
+	mu         sync.Mutex // protects following fields.
+	tokens     int        // the available tokens, negative means reserved by the waiters.
+	refilling  bool       // the refill task is scheduled.
+	nextRefill time.Time  // the time of the next refill.
+}
+
+// NewTokenBucket new token-bucket limiter, which is full initially.
+// every and burst must be greater than zero; if not, NewTokenBucket will panic.
+func NewTokenBucket(t *timer.Timer, every time.Duration, burst int) *TokenBucket {
+	if every <= 0 || burst <= 0 {
+		panic("ratelimit: non-positive interval or burst for NewTokenBucket")
+	}
+	tb := &TokenBucket{
+		timer:  t,
+		every:  every,
+		burst:  burst,
+		tokens: burst,
+	}
+	tb.refill = timer.NewTaskFunc(every, tb.onRefill).WithNoSkip().WithNoSpreading()
+	return tb
+}
+
+// Allow implements Limiter.
+func (tb *TokenBucket) Allow() bool {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	if tb.tokens <= 0 {
+		return false
+	}
+	tb.tokens--
+	tb.scheduleRefill()
+	return true
+}
+
+// Reserve implements Limiter.
+func (tb *TokenBucket) Reserve() *Reservation {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	now := time.Now()
+	if tb.tokens > 0 {
+		tb.tokens--
+		tb.scheduleRefill()
+		return &Reservation{timeToAct: now, cancel: tb.giveBack}
+	}
+	if err := tb.scheduleRefill(); err != nil {
+		return &Reservation{err: err}
+	}
+	// the waiters before take the refills in order.
+	timeToAct := tb.nextRefill.Add(time.Duration(-tb.tokens) * tb.every)
+	tb.tokens--
+	return &Reservation{timeToAct: timeToAct, cancel: tb.giveBack}
+}
+
+// Wait implements Limiter.
+func (tb *TokenBucket) Wait(ctx context.Context) error {
+	return wait(ctx, tb.timer, tb.Reserve())
+}
+
+// Tokens returns the available tokens, negative means reserved by the waiters.
+func (tb *TokenBucket) Tokens() int {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	return tb.tokens
+}
+
+func (tb *TokenBucket) idle() bool {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	return tb.tokens == tb.burst
+}
+
+func (tb *TokenBucket) giveBack() {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	tb.tokens = min(tb.tokens+1, tb.burst)
+}
+
+func (tb *TokenBucket) onRefill() {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	tb.refilling = false
+	tb.tokens = min(tb.tokens+1, tb.burst)
+	_ = tb.scheduleRefill()
+}
+
+// NOTE: should be call when `TokenBucket.mu` lock.
+func (tb *TokenBucket) scheduleRefill() error {
+	if tb.refilling || tb.tokens >= tb.burst {
+		return nil
+	}
+	if err := tb.timer.AddTask(tb.refill); err != nil {
+		return err
+	}
+	tb.refilling = true
+	tb.nextRefill = time.Now().Add(tb.every)
+	return nil
+}
@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thinkgos/timer"
+)
+
+func Test_TokenBucket(t *testing.T) {
+	tm := timer.NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	require.Panics(t, func() { NewTokenBucket(tm, 0, 1) })
+	require.Panics(t, func() { NewTokenBucket(tm, time.Second, 0) })
+
+	tb := NewTokenBucket(tm, 20*time.Millisecond, 3)
+	require.True(t, tb.idle())
+	for i := 0; i < 3; i++ {
+		require.True(t, tb.Allow())
+	}
+	require.False(t, tb.Allow())
+	require.Zero(t, tb.Tokens())
+
+	// refill
+	require.Eventually(t, tb.Allow, time.Second, time.Millisecond)
+	require.Eventually(t, tb.idle, time.Second, time.Millisecond)
+}
+
+func Test_TokenBucket_Reserve(t *testing.T) {
+	tm := timer.NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	tb := NewTokenBucket(tm, 20*time.Millisecond, 1)
+	r := tb.Reserve()
+	require.True(t, r.OK())
+	require.Zero(t, r.Delay())
+
+	r1 := tb.Reserve()
+	r2 := tb.Reserve()
+	require.True(t, r1.OK())
+	require.InDelta(t, 20*time.Millisecond, r1.Delay(), float64(5*time.Millisecond))
+	require.InDelta(t, 40*time.Millisecond, r2.Delay(), float64(5*time.Millisecond))
+	require.Equal(t, -2, tb.Tokens())
+
+	r2.Cancel()
+	r2.Cancel()
+	require.Equal(t, -1, tb.Tokens())
+	require.False(t, tb.Allow())
+	require.Eventually(t, tb.idle, time.Second, time.Millisecond)
+
+	// timer closed
+	tb = NewTokenBucket(timer.NewTimer(), time.Second, 1)
+	require.True(t, tb.Reserve().OK())
+	r = tb.Reserve()
+	require.False(t, r.OK())
+	require.Zero(t, r.Delay())
+	require.ErrorIs(t, tb.Wait(context.Background()), timer.ErrClosed)
+}
+
+func Test_TokenBucket_Wait(t *testing.T) {
+	tm := timer.NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	tb := NewTokenBucket(tm, 20*time.Millisecond, 1)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		require.NoError(t, tb.Wait(context.Background()))
+	}
+	require.GreaterOrEqual(t, time.Since(start), 55*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, tb.Wait(ctx), ErrWouldExceedDeadline)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(5*time.Millisecond, cancel)
+	require.ErrorIs(t, tb.Wait(ctx), context.Canceled)
+	require.ErrorIs(t, tb.Wait(ctx), context.Canceled)
+	// the permits of the canceled waiters are returned.
+	require.Eventually(t, tb.idle, time.Second, time.Millisecond)
+}
@@ -0,0 +1,117 @@
+// Package session tracks idle sessions, and calls the timeout handler
+// once a session has been idle for the timeout.
+package session
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/thinkgos/timer"
+)
+
+// ErrExists is returned when the session id is already registered.
+var ErrExists = errors.New("session: already exists")
+
+type session struct {
+	lastMs    atomic.Int64 // the last activity time, unit is milliseconds.
+	task      *timer.Task
+	onTimeout func()
+}
+
+// Manager tracks the idle timeout of the sessions, it is safe for concurrent use.
+// Touch only records the activity time, the expiry is pushed back lazily when
+// the session's task fires, so refreshes are coalesced and never churn the wheel.
+type Manager[K comparable] struct {
+	timer     *timer.Timer
+	timeoutMs int64
+	mu        sync.RWMutex // protects following fields.
+	sessions  map[K]*session
+}
+
+// New new session manager, sessions idle for the timeout are closed.
+func New[K comparable](t *timer.Timer, timeout time.Duration) *Manager[K] {
+	return &Manager[K]{
+		timer:     t,
+		timeoutMs: max(timeout.Milliseconds(), 1),
+		sessions:  make(map[K]*session),
+	}
+}
+
+// Register a session with a closer, which is closed when the session times out.
+func (m *Manager[K]) Register(id K, c io.Closer) error {
+	return m.RegisterFunc(id, func() { _ = c.Close() })
+}
+
+// RegisterFunc register a session with a callback, which is called
+// through the timer's `GoPool` when the session times out.
+func (m *Manager[K]) RegisterFunc(id K, onTimeout func()) error {
+	s := &session{onTimeout: onTimeout}
+	s.lastMs.Store(time.Now().UnixMilli())
+	s.task = timer.NewTaskFunc(time.Duration(m.timeoutMs)*time.Millisecond, func() { m.expire(id, s) }).WithNoSkip().WithNoSpreading()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.sessions[id]; ok {
+		return ErrExists
+	}
+	if err := m.timer.AddTask(s.task); err != nil {
+		return err
+	}
+	m.sessions[id] = s
+	return nil
+}
+
+// Touch records an activity of the session, push back its expiry.
+// It returns false if the session not exist.
+func (m *Manager[K]) Touch(id K) bool {
+	m.mu.RLock()
+	s, ok := m.sessions[id]
+	m.mu.RUnlock()
+	if ok {
+		s.lastMs.Store(time.Now().UnixMilli())
+	}
+	return ok
+}
+
+// Close stops tracking the session, the timeout handler is not called.
+// It returns false if the session not exist.
+func (m *Manager[K]) Close(id K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.sessions[id]
+	if ok {
+		s.task.Cancel()
+		delete(m.sessions, id)
+	}
+	return ok
+}
+
+// Len returns the number of the sessions.
+func (m *Manager[K]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.sessions)
+}
+
+func (m *Manager[K]) expire(id K, s *session) {
+	m.mu.Lock()
+	if m.sessions[id] != s {
+		m.mu.Unlock()
+		return
+	}
+	// touched since scheduled, push back the expiry.
+	if remainMs := s.lastMs.Load() + m.timeoutMs - time.Now().UnixMilli(); remainMs > 0 {
+		err := m.timer.AddTask(s.task.SetDelay(time.Duration(remainMs) * time.Millisecond))
+		if err == nil {
+			m.mu.Unlock()
+			return
+		}
+	}
+	delete(m.sessions, id)
+	m.mu.Unlock()
+	// already in the timer's `GoPool`.
+	s.onTimeout()
+}
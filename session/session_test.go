@@ -0,0 +1,85 @@
+package session
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thinkgos/timer"
+)
+
+type closer struct {
+	closed atomic.Bool
+}
+
+func (c *closer) Close() error {
+	c.closed.Store(true)
+	return nil
+}
+
+func Test_Manager(t *testing.T) {
+	tm := timer.NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	m := New[int](tm, 40*time.Millisecond)
+	active, idle, closed := &closer{}, &closer{}, &closer{}
+	require.NoError(t, m.Register(1, active))
+	require.NoError(t, m.Register(2, idle))
+	require.NoError(t, m.Register(3, closed))
+	require.ErrorIs(t, m.Register(1, active), ErrExists)
+	require.Equal(t, 3, m.Len())
+
+	require.True(t, m.Close(3))
+	require.False(t, m.Close(3))
+	require.False(t, m.Touch(3))
+
+	for i := 0; i < 10; i++ { // 100ms, keep session 1 alive.
+		require.True(t, m.Touch(1))
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.True(t, idle.closed.Load())
+	require.False(t, active.closed.Load())
+	require.False(t, closed.closed.Load())
+	require.Equal(t, 1, m.Len())
+
+	require.Eventually(t, active.closed.Load, time.Second, time.Millisecond)
+	require.Zero(t, m.Len())
+}
+
+func Test_Manager_RegisterFunc(t *testing.T) {
+	tm := timer.NewTimer()
+	m := New[string](tm, 10*time.Millisecond)
+	require.ErrorIs(t, m.RegisterFunc("a", func() {}), timer.ErrClosed)
+	require.Zero(t, m.Len())
+
+	tm.Start()
+	defer tm.Stop()
+
+	fired := make(chan string, 1)
+	require.NoError(t, m.RegisterFunc("a", func() { fired <- "a" }))
+	start := time.Now()
+	require.Equal(t, "a", <-fired)
+	require.GreaterOrEqual(t, time.Since(start), 9*time.Millisecond)
+	require.False(t, m.Touch("a"))
+}
+
+func Benchmark_Manager_Touch(b *testing.B) {
+	tm := timer.NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	m := New[int](tm, time.Hour)
+	for i := 0; i < 1024; i++ {
+		_ = m.RegisterFunc(i, func() {})
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			m.Touch(i & 1023)
+			i++
+		}
+	})
+}
@@ -3,7 +3,6 @@ package timer
 import (
 	"sync"
 	"sync/atomic"
-	"time"
 )
 
 // Spoke a spoke of the wheel.
@@ -12,11 +11,17 @@ type Spoke struct {
 	expiration  atomic.Int64  // the expiration time
 	mu          sync.Mutex    // protects all list's action.
 	taskCounter *atomic.Int64 // same as Timer.taskCounter
+	clock       *clock        // the time base, same as Timer.clock
 }
 
 func NewSpoke(taskCounter *atomic.Int64) *Spoke {
+	return newSpoke(taskCounter, processClock)
+}
+
+func newSpoke(taskCounter *atomic.Int64, c *clock) *Spoke {
 	sp := &Spoke{
 		taskCounter: taskCounter,
+		clock:       c,
 	}
 	sp.expiration.Store(-1)
 	sp.root.next = &sp.root
@@ -42,13 +47,67 @@ func (sp *Spoke) Add(te *taskEntry) {
 	}
 }
 
+// addBatch add the new task entries to this list, locking once.
+// NOTE: the task entries must not belong to any list.
+func (sp *Spoke) addBatch(tes []*taskEntry) {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	for _, te := range tes {
+		sp.pushBack(te)
+	}
+}
+
+// removeBatch remove the task entries which still belong to this list, locking once.
+// It moves the removed ones to the front of the slice, and returns the number of them.
+func (sp *Spoke) removeBatch(tes []*taskEntry) int {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	n := 0
+	for i, te := range tes {
+		if te.list.Load() == sp {
+			sp.remove(te)
+			tes[n], tes[i] = tes[i], tes[n]
+			n++
+		}
+	}
+	return n
+}
+
+// spokeBatch collects the consecutive task entries of the same spoke, and applies them together.
+type spokeBatch struct {
+	spoke   *Spoke
+	entries []*taskEntry
+	apply   func(spoke *Spoke, tes []*taskEntry)
+}
+
+// add the task entry of the spoke, the collected ones are applied if the spoke changes.
+func (b *spokeBatch) add(spoke *Spoke, te *taskEntry) {
+	if spoke != b.spoke {
+		b.flush()
+		b.spoke = spoke
+	}
+	b.entries = append(b.entries, te)
+}
+
+// flush apply the collected task entries.
+func (b *spokeBatch) flush() {
+	if len(b.entries) > 0 {
+		b.apply(b.spoke, b.entries)
+		clear(b.entries)
+		b.entries = b.entries[:0]
+	}
+}
+
 // Remove the specified timer task from this list
-func (sp *Spoke) Remove(te *taskEntry) {
+// Returns true if the timer task is removed.
+func (sp *Spoke) Remove(te *taskEntry) bool {
 	sp.mu.Lock()
 	defer sp.mu.Unlock()
 	if te.list.Load() == sp {
 		sp.remove(te)
+		return true
 	}
+	return false
 }
 
 func (sp *Spoke) pushBack(te *taskEntry) {
@@ -59,7 +118,9 @@ func (sp *Spoke) pushBack(te *taskEntry) {
 	te.prev.next = te
 	te.next.prev = te
 	te.list.Store(sp)
-	sp.taskCounter.Add(1)
+	if !te.task.internal {
+		sp.taskCounter.Add(1)
+	}
 }
 
 func (sp *Spoke) remove(te *taskEntry) {
@@ -68,7 +129,9 @@ func (sp *Spoke) remove(te *taskEntry) {
 	te.next = nil // avoid memory leaks
 	te.prev = nil // avoid memory leaks
 	te.list.Store(nil)
-	sp.taskCounter.Add(-1)
+	if !te.task.internal {
+		sp.taskCounter.Add(-1)
+	}
 }
 
 // Flush all task entries and apply the supplied function to each of them
@@ -82,6 +145,19 @@ func (sp *Spoke) Flush(f func(*taskEntry)) {
 	sp.SetExpiration(-1)
 }
 
+// rangeTaskEntry calls f for each task entry in this list, in insertion order.
+// If f returns false, rangeTaskEntry stops the iteration.
+// NOTE: f is called with the spoke locked, it must not modify the spoke.
+func (sp *Spoke) rangeTaskEntry(f func(*taskEntry) bool) {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	for e := sp.root.next; e != &sp.root; e = e.next {
+		if !f(e) {
+			return
+		}
+	}
+}
+
 // SetExpiration set the spoke's expiration time
 // Returns true if the expiration time changes.
 func (sp *Spoke) SetExpiration(expirationMs int64) bool {
@@ -93,7 +169,7 @@ func (sp *Spoke) GetExpiration() int64 { return sp.expiration.Load() }
 
 // Delay implements delayqueue.Delayed.
 func (sp *Spoke) Delay() int64 {
-	delay := sp.GetExpiration() - time.Now().UnixMilli()
+	delay := sp.GetExpiration() - sp.clock.nowMs()
 	if delay < 0 {
 		return 0
 	}
@@ -28,12 +28,12 @@ func Test_Spoke(t *testing.T) {
 
 func Test_Spoke_Task(t *testing.T) {
 	tasks := map[*taskEntry]struct{}{
-		newTaskEntry(NewTask(101 * time.Millisecond)): {},
-		newTaskEntry(NewTask(102 * time.Millisecond)): {},
-		newTaskEntry(NewTask(103 * time.Millisecond)): {},
-		newTaskEntry(NewTask(105 * time.Millisecond)): {},
+		newTaskEntry(NewTask(101*time.Millisecond), 101): {},
+		newTaskEntry(NewTask(102*time.Millisecond), 102): {},
+		newTaskEntry(NewTask(103*time.Millisecond), 103): {},
+		newTaskEntry(NewTask(105*time.Millisecond), 105): {},
 	}
-	task1 := newTaskEntry(NewTask(104 * time.Millisecond))
+	task1 := newTaskEntry(NewTask(104*time.Millisecond), 104)
 
 	taskCounter := &atomic.Int64{}
 	spoke := NewSpoke(taskCounter)
@@ -52,3 +52,31 @@ func Test_Spoke_Task(t *testing.T) {
 	})
 	require.Equal(t, int64(0), taskCounter.Load())
 }
+
+func Test_SpokeBatch(t *testing.T) {
+	spokes := []*Spoke{NewSpoke(&atomic.Int64{}), NewSpoke(&atomic.Int64{}), NewSpoke(&atomic.Int64{})}
+	applied := make(map[*Spoke][]int64)
+	calls := 0
+	b := spokeBatch{apply: func(spoke *Spoke, tes []*taskEntry) {
+		calls++
+		for _, te := range tes {
+			applied[spoke] = append(applied[spoke], te.expirationMs)
+		}
+	}}
+	// only the consecutive task entries of a spoke are applied together, in the order added.
+	for i, s := range []int{0, 1, 0, 2, 1, 1, 0} {
+		b.add(spokes[s], &taskEntry{expirationMs: int64(i)})
+	}
+	b.flush()
+	require.Equal(t, 6, calls)
+	require.Equal(t, []int64{0, 2, 6}, applied[spokes[0]])
+	require.Equal(t, []int64{1, 4, 5}, applied[spokes[1]])
+	require.Equal(t, []int64{3}, applied[spokes[2]])
+
+	// reusable after flush.
+	b.add(spokes[2], &taskEntry{expirationMs: 7})
+	b.flush()
+	b.flush()
+	require.Equal(t, 7, calls)
+	require.Equal(t, []int64{3, 7}, applied[spokes[2]])
+}
@@ -0,0 +1,224 @@
+package timer
+
+import (
+	"sync"
+	"time"
+)
+
+// StdTimer is a drop-in replacement of time.Timer backed by a Timer.
+// It follows the Go 1.23 semantics, after Stop or Reset returns,
+// no stale value will be received from C.
+// NOTE: if the Timer is not started, the StdTimer never fires.
+type StdTimer struct {
+	C <-chan time.Time // The channel on which the time is delivered, nil if created by StdAfterFunc.
+
+	c      chan time.Time
+	f      func() // the function of StdAfterFunc.
+	timer  *Timer
+	mu     sync.Mutex // protects following fields.
+	task   *Task      // the task of the current schedule.
+	seq    uint64     // increase on each schedule, the task with stale seq has no effect.
+	active bool       // true if scheduled and not yet fired or stopped.
+}
+
+// NewStdTimer creates a new StdTimer that will send the current time on its channel after at least duration d.
+// same as time.NewTimer.
+func (t *Timer) NewStdTimer(d time.Duration) *StdTimer {
+	c := make(chan time.Time, 1)
+	st := &StdTimer{
+		C:     c,
+		c:     c,
+		timer: t,
+	}
+	st.schedule(d)
+	return st
+}
+
+// StdAfterFunc waits for the duration to elapse and then calls f in the `GoPool`.
+// It returns a StdTimer that can be used to cancel the call using its Stop method.
+// same as time.AfterFunc.
+func (t *Timer) StdAfterFunc(d time.Duration, f func()) *StdTimer {
+	st := &StdTimer{
+		f:     f,
+		timer: t,
+	}
+	st.schedule(d)
+	return st
+}
+
+// Stop prevents the StdTimer from firing.
+// It returns true if the call stops the timer, false if the timer has already expired or been stopped.
+// same as time.Timer.Stop.
+func (st *StdTimer) Stop() bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.stop()
+}
+
+// Reset changes the timer to expire after duration d.
+// It returns true if the timer had been active, false if the timer had expired or been stopped.
+// same as time.Timer.Reset.
+func (st *StdTimer) Reset(d time.Duration) bool {
+	st.mu.Lock()
+	active := st.stop()
+	st.mu.Unlock()
+	st.schedule(d)
+	return active
+}
+
+// NOTE: should be call when `StdTimer.mu` lock.
+func (st *StdTimer) stop() bool {
+	active := st.active
+	st.active = false
+	st.seq++
+	if st.task != nil {
+		st.task.Cancel()
+		st.task = nil
+	}
+	if st.c != nil {
+		// the value is not yet received, treat as not fired.
+		select {
+		case <-st.c:
+			active = true
+		default:
+		}
+	}
+	return active
+}
+
+func (st *StdTimer) schedule(d time.Duration) {
+	st.mu.Lock()
+	st.seq++
+	seq := st.seq
+	task := NewTaskFunc(d, func() { st.fire(seq) }).WithNoSkip().WithNoSpreading()
+	st.task = task
+	st.active = true
+	st.mu.Unlock()
+	// NOTE: add task without lock, the task may run immediately in the `GoPool`.
+	if err := st.timer.AddTask(task); err != nil {
+		st.mu.Lock()
+		if st.seq == seq {
+			st.active = false
+		}
+		st.mu.Unlock()
+	}
+}
+
+func (st *StdTimer) fire(seq uint64) {
+	st.mu.Lock()
+	if st.seq != seq || !st.active {
+		st.mu.Unlock()
+		return
+	}
+	st.active = false
+	if st.f != nil {
+		st.mu.Unlock()
+		st.f()
+		return
+	}
+	select {
+	case st.c <- time.Now():
+	default:
+	}
+	st.mu.Unlock()
+}
+
+// StdTicker is a drop-in replacement of time.Ticker backed by a Timer.
+// It delivers ticks at intervals, drops ticks to make up for slow receivers,
+// and follows the Go 1.23 semantics, after Stop or Reset returns,
+// no stale value will be received from C.
+// NOTE: if the Timer is not started, the StdTicker never ticks.
+type StdTicker struct {
+	C <-chan time.Time // The channel on which the ticks are delivered.
+
+	c     chan time.Time
+	timer *Timer
+	mu    sync.Mutex // protects following fields.
+	task  *Task      // the task of the current schedule.
+	seq   uint64     // increase on each schedule, the task with stale seq has no effect.
+}
+
+// NewStdTicker returns a new StdTicker containing a channel that will send the current time
+// on the channel after each tick. The period of the ticks is specified by the duration argument.
+// The duration d must be greater than zero; if not, NewStdTicker will panic.
+// same as time.NewTicker.
+func (t *Timer) NewStdTicker(d time.Duration) *StdTicker {
+	if d <= 0 {
+		panic("timer: non-positive interval for NewStdTicker")
+	}
+	c := make(chan time.Time, 1)
+	st := &StdTicker{
+		C:     c,
+		c:     c,
+		timer: t,
+	}
+	st.schedule(d)
+	return st
+}
+
+// Stop turns off a ticker. After Stop, no more ticks will be sent.
+// same as time.Ticker.Stop.
+func (st *StdTicker) Stop() {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.stop()
+}
+
+// Reset stops a ticker and resets its period to the specified duration.
+// The next tick will arrive after the new period elapses.
+// The duration d must be greater than zero; if not, Reset will panic.
+// same as time.Ticker.Reset.
+func (st *StdTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("timer: non-positive interval for StdTicker.Reset")
+	}
+	st.mu.Lock()
+	st.stop()
+	st.mu.Unlock()
+	st.schedule(d)
+}
+
+// NOTE: should be call when `StdTicker.mu` lock.
+func (st *StdTicker) stop() {
+	st.seq++
+	if st.task != nil {
+		st.task.Cancel()
+		st.task = nil
+	}
+	select {
+	case <-st.c:
+	default:
+	}
+}
+
+func (st *StdTicker) schedule(period time.Duration) {
+	st.mu.Lock()
+	st.seq++
+	seq := st.seq
+	next := time.Now().Add(period)
+	task := NewTask(period).WithNoSkip().WithNoSpreading()
+	task.WithJobFunc(func() {
+		st.mu.Lock()
+		if st.seq != seq {
+			st.mu.Unlock()
+			return
+		}
+		now := time.Now()
+		select {
+		case st.c <- now:
+		default:
+		}
+		// next tick base on the previous one to avoid drift, skip the missed ticks.
+		next = next.Add(period)
+		if lag := now.Sub(next); lag >= 0 {
+			next = next.Add((lag/period + 1) * period)
+		}
+		task.SetDelay(next.Sub(now))
+		st.mu.Unlock()
+		_ = st.timer.AddTask(task)
+	})
+	st.task = task
+	st.mu.Unlock()
+	// NOTE: add task without lock, the task may run immediately in the `GoPool`.
+	_ = st.timer.AddTask(task)
+}
@@ -0,0 +1,154 @@
+package timer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_StdTimer(t *testing.T) {
+	tm := NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	t.Run("fire", func(t *testing.T) {
+		start := time.Now()
+		st := tm.NewStdTimer(20 * time.Millisecond)
+		v := <-st.C
+		require.GreaterOrEqual(t, v.Sub(start), 19*time.Millisecond)
+		require.False(t, st.Stop())
+		require.False(t, st.Reset(time.Hour))
+		require.True(t, st.Stop())
+	})
+
+	t.Run("stop before fire", func(t *testing.T) {
+		st := tm.NewStdTimer(20 * time.Millisecond)
+		require.True(t, st.Stop())
+		require.False(t, st.Stop())
+		select {
+		case <-st.C:
+			t.Fatal("stopped timer should not fire")
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("stop after fire without receive", func(t *testing.T) {
+		st := tm.NewStdTimer(time.Millisecond)
+		time.Sleep(20 * time.Millisecond)
+		require.True(t, st.Stop())
+		select {
+		case <-st.C:
+			t.Fatal("no stale value after stop")
+		default:
+		}
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		st := tm.NewStdTimer(time.Millisecond)
+		time.Sleep(20 * time.Millisecond)
+		require.True(t, st.Reset(30*time.Millisecond))
+		start := time.Now()
+		select {
+		case <-st.C:
+			t.Fatal("no stale value after reset")
+		default:
+		}
+		v := <-st.C
+		require.GreaterOrEqual(t, v.Sub(start), 25*time.Millisecond)
+
+		// reset an active timer
+		require.False(t, st.Reset(time.Hour))
+		require.True(t, st.Reset(10*time.Millisecond))
+		<-st.C
+	})
+
+	t.Run("after func", func(t *testing.T) {
+		fired := make(chan struct{}, 1)
+		st := tm.StdAfterFunc(10*time.Millisecond, func() { fired <- struct{}{} })
+		require.Nil(t, st.C)
+		<-fired
+		require.False(t, st.Stop())
+
+		st = tm.StdAfterFunc(20*time.Millisecond, func() { fired <- struct{}{} })
+		require.True(t, st.Stop())
+		require.False(t, st.Reset(10*time.Millisecond))
+		<-fired
+	})
+
+	t.Run("timer closed", func(t *testing.T) {
+		st := NewTimer().NewStdTimer(time.Millisecond)
+		require.False(t, st.Stop())
+	})
+}
+
+func Test_StdTicker(t *testing.T) {
+	tm := NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	require.Panics(t, func() { tm.NewStdTicker(0) })
+
+	start := time.Now()
+	st := tm.NewStdTicker(10 * time.Millisecond)
+	for i := 1; i <= 5; i++ {
+		<-st.C
+	}
+	require.GreaterOrEqual(t, time.Since(start), 45*time.Millisecond)
+
+	// slow receiver drops ticks.
+	time.Sleep(50 * time.Millisecond)
+	require.Len(t, st.C, 1)
+
+	// reset
+	require.Panics(t, func() { st.Reset(-1) })
+	st.Reset(30 * time.Millisecond)
+	require.Len(t, st.C, 0)
+	start = time.Now()
+	<-st.C
+	require.GreaterOrEqual(t, time.Since(start), 25*time.Millisecond)
+
+	// stop
+	st.Stop()
+	select {
+	case <-st.C:
+		t.Fatal("stopped ticker should not tick")
+	case <-time.After(70 * time.Millisecond):
+	}
+}
+
+func Test_StdTicker_NoDrift(t *testing.T) {
+	tm := NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	ticks := 0
+	st := tm.NewStdTicker(5 * time.Millisecond)
+	defer st.Stop()
+	done := time.After(200 * time.Millisecond)
+	for running := true; running; {
+		select {
+		case <-st.C:
+			ticks++
+		case <-done:
+			running = false
+		}
+	}
+	require.InDelta(t, 40, ticks, 8)
+}
+
+func Test_Default_Std(t *testing.T) {
+	require.Nil(t, Tick(0))
+	<-After(10 * time.Millisecond)
+	<-Tick(10 * time.Millisecond)
+
+	st := NewStdTimer(10 * time.Millisecond)
+	<-st.C
+	ticker := NewStdTicker(10 * time.Millisecond)
+	<-ticker.C
+	ticker.Stop()
+
+	fired := make(chan struct{})
+	StdAfterFunc(10*time.Millisecond, func() { close(fired) })
+	<-fired
+}
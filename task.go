@@ -1,6 +1,8 @@
 package timer
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"sync"
@@ -19,16 +21,50 @@ type JobFunc func()
 // Run implement job interface
 func (f JobFunc) Run() { f() }
 
+// JobContext job interface aware of the context, the context is cancelled when the job times out.
+type JobContext interface {
+	Job
+	RunContext(ctx context.Context) error
+}
+
+// JobContextFunc job function aware of the context
+type JobContextFunc func(ctx context.Context) error
+
+// Run implement job interface
+func (f JobContextFunc) Run() { _ = f(context.Background()) }
+
+// RunContext implement JobContext interface
+func (f JobContextFunc) RunContext(ctx context.Context) error { return f(ctx) }
+
+// ErrJobTimeout is reported when the job runs longer than the task's timeout.
+var ErrJobTimeout = errors.New("timer: job timeout")
+
 var emptyJob = JobFunc(func() {})
 var _ DerefTask = (*Task)(nil)
 var _ Job = (*Task)(nil)
 
 // Task timer task.
 type Task struct {
-	delay     atomic.Int64 // delay duration
-	job       Job          // the job of future execution
-	rw        sync.RWMutex // protects following fields.
-	taskEntry *taskEntry   // the taskEntry to which the task belongs.
+	delay          atomic.Int64                             // delay duration
+	atMs           atomic.Int64                             // the absolute expiration, unix milliseconds, 0 means relative to the time added.
+	job            Job                                      // the job of future execution
+	inline         bool                                     // run the job inline in the timer's goroutine instead of `GoPool`, the job must not block.
+	internal       bool                                     // the task is scheduled by the timer itself, no jitter, never skipped, hidden from the introspection.
+	noSkip         bool                                     // the job is never skipped by BusyPolicy_Skip or the misfire policy.
+	timeout        time.Duration                            // the timeout of the job, 0 means no timeout.
+	onError        func(task *Task, err error)              // the error handler of the job.
+	group          atomic.Pointer[TaskGroup]                // the group to which the task belongs.
+	priority       int                                      // the priority of the job, higher runs first among the tasks expired at the same time.
+	lane           string                                   // the lane key, the jobs sharing a lane run sequentially.
+	jitter         time.Duration                            // the maximum random delay added to the expiration.
+	jitterFraction float64                                  // the maximum random delay added to the expiration, as a fraction of the delay.
+	noSpreading    bool                                     // the expiration is not spread by the timer, see WithSpreading.
+	tolerance      time.Duration                            // the lateness the task tolerates, the timer may fire it in [expiry, expiry+tolerance].
+	misfire        time.Duration                            // the misfire threshold, 0 means use the timer's.
+	misfirePolicy  MisfirePolicy                            // the misfire policy, used with the misfire threshold.
+	onMisfire      func(task *Task, lateness time.Duration) // the misfire handler, used with MisfirePolicy_Handler.
+	rw             sync.RWMutex                             // protects following fields.
+	taskEntry      *taskEntry                               // the taskEntry to which the task belongs.
 }
 
 // NewTask new task with delay duration and an empty job, the accuracy is milliseconds.
@@ -38,11 +74,22 @@ func NewTask(d time.Duration) *Task {
 	return t
 }
 
+// NewTaskAt new task expires at the absolute wall clock time and an empty job, the accuracy is milliseconds.
+// see WithClockJumpPolicy for the behavior when the wall clock jumps.
+func NewTaskAt(at time.Time) *Task {
+	return NewTask(0).SetAt(at)
+}
+
 // NewTaskFunc new task with delay duration and a function job, the accuracy is milliseconds.
 func NewTaskFunc(d time.Duration, f func()) *Task {
 	return NewTask(d).WithJobFunc(f)
 }
 
+// NewTaskContextFunc new task with delay duration and a function job aware of the context, the accuracy is milliseconds.
+func NewTaskContextFunc(d time.Duration, f func(ctx context.Context) error) *Task {
+	return NewTask(d).WithJobContextFunc(f)
+}
+
 // NewTaskJob new task with delay duration and a job, the accuracy is milliseconds.
 func NewTaskJob(d time.Duration, job Job) *Task {
 	return NewTask(d).WithJob(job)
@@ -54,33 +101,179 @@ func (t *Task) WithJobFunc(f func()) *Task {
 	return t
 }
 
-// WithJob with a job
+// WithJobContextFunc with a function job aware of the context
+func (t *Task) WithJobContextFunc(f func(ctx context.Context) error) *Task {
+	t.job = JobContextFunc(f)
+	return t
+}
+
+// WithJob with a job, the job can implement JobContext to be aware of the context.
 func (t *Task) WithJob(j Job) *Task {
 	t.job = j
 	return t
 }
 
+// WithTimeout with the timeout of the job, the context of a JobContext is cancelled
+// when the job times out, and ErrJobTimeout is reported to the error handler.
+// NOTE: a job not aware of the context can not be cancelled, the timeout is reported after it returns.
+func (t *Task) WithTimeout(d time.Duration) *Task {
+	t.timeout = d
+	return t
+}
+
+// WithErrorHandler with the error handler of the job, which receives the error returned
+// by a JobContext, or ErrJobTimeout. default print to the stderr.
+func (t *Task) WithErrorHandler(f func(task *Task, err error)) *Task {
+	t.onError = f
+	return t
+}
+
+// WithPriority with the priority of the job, default 0.
+// the tasks expired in the same advance batch are dispatched in priority order, higher first,
+// and the queued jobs under the limit of concurrent jobs run in priority order too.
+// NOTE: without WithMaxConcurrentJobs, the jobs are handed to the `GoPool` in priority order,
+// but run concurrently, the order they start in is up to the `GoPool`.
+func (t *Task) WithPriority(p int) *Task {
+	t.priority = p
+	return t
+}
+
+// Priority return the priority of the job.
+func (t *Task) Priority() int { return t.priority }
+
+// WithLane with the lane key, the jobs of the tasks sharing a lane run sequentially
+// in expiry order, while different lanes run in parallel, empty means no lane.
+// NOTE: the tasks of a lane expired in the same advance batch run in priority order.
+func (t *Task) WithLane(key string) *Task {
+	t.lane = key
+	return t
+}
+
+// Lane return the lane key.
+func (t *Task) Lane() string { return t.lane }
+
+// WithNoSkip the job is never skipped, it is queued instead under BusyPolicy_Skip,
+// and dispatched regardless of the misfire policy.
+// for the task re-adds itself in the job, like a ticker, skipping the job stops it forever.
+func (t *Task) WithNoSkip() *Task {
+	t.noSkip = true
+	return t
+}
+
+// skippable report whether the job of the task can be skipped.
+func (t *Task) skippable() bool { return !t.internal && !t.noSkip }
+
+// WithJitter with the maximum random delay added to the expiration, the task fires in [delay, delay+max].
+// if WithJitterFraction is set too, the larger one applies.
+func (t *Task) WithJitter(max time.Duration) *Task {
+	t.jitter = max
+	return t
+}
+
+// WithJitterFraction with the maximum random delay added to the expiration, as a fraction of the delay,
+// the task fires in [delay, delay*(1+f)]. if WithJitter is set too, the larger one applies.
+func (t *Task) WithJitterFraction(f float64) *Task {
+	t.jitterFraction = f
+	return t
+}
+
+// WithNoSpreading the expiration of the task without jitter is not spread by the timer, see WithSpreading.
+// for the task must fire on time, like a drop-in replacement of time.Timer.
+func (t *Task) WithNoSpreading() *Task {
+	t.noSpreading = true
+	return t
+}
+
+// WithTolerance with the lateness the task tolerates, the timer may fire the task anywhere
+// in [expiry, expiry+d], so that it can share a spoke with other tasks, like the timer slack of the kernel.
+func (t *Task) WithTolerance(d time.Duration) *Task {
+	t.tolerance = d
+	return t
+}
+
+// Tolerance return the lateness the task tolerates.
+func (t *Task) Tolerance() time.Duration { return t.tolerance }
+
+// WithMisfireThreshold with the misfire threshold and policy, override the timer's, 0 means use the timer's.
+// the task fires later than its expiry plus tolerance plus threshold is a misfire, the policy applies.
+// NOTE: the inline job and the job of the task WithNoSkip are never a misfire.
+func (t *Task) WithMisfireThreshold(threshold time.Duration, policy MisfirePolicy) *Task {
+	t.misfire = threshold
+	t.misfirePolicy = policy
+	return t
+}
+
+// WithOnMisfire with the misfire handler, override the timer's, used with MisfirePolicy_Handler.
+// the handler is called with the lateness instead of the job.
+func (t *Task) WithOnMisfire(f func(task *Task, lateness time.Duration)) *Task {
+	t.onMisfire = f
+	return t
+}
+
+// Timeout return the timeout of the job.
+func (t *Task) Timeout() time.Duration { return t.timeout }
+
 // DerefTask implements TaskContainer.
 func (t *Task) DerefTask() *Task { return t }
 
 // Run immediate call job. implement Job interface.
-func (t *Task) Run() {
+func (t *Task) Run() { t.runContext(context.Background()) }
+
+// runContext call the job with the context derived from ctx.
+func (t *Task) runContext(ctx context.Context) {
 	defer func() {
 		if err := recover(); err != nil {
 			fmt.Fprintf(os.Stderr, "timer: Recovered from panic: %v\n", err)
 		}
 	}()
-	t.job.Run()
+	if t.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+		defer cancel()
+	}
+	var err error
+	if j, ok := t.job.(JobContext); ok {
+		err = j.RunContext(ctx)
+	} else {
+		t.job.Run()
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = ErrJobTimeout
+	}
+	if err != nil {
+		if t.onError != nil {
+			t.onError(t, err)
+		} else {
+			fmt.Fprintf(os.Stderr, "timer: job error: %v\n", err)
+		}
+	}
 }
 
 // Cancel the task.
 func (t *Task) Cancel() {
+	t.cancel()
+	if g := t.group.Load(); g != nil {
+		g.remove(t)
+	}
+}
+
+// cancel the task, It returns true if the pending task entry is removed by this call,
+// otherwise the task entry is not pending, or is being fired by the timer.
+func (t *Task) cancel() bool {
 	t.rw.Lock()
-	defer t.rw.Unlock()
-	if t.taskEntry != nil {
-		t.taskEntry.remove()
-		t.taskEntry = nil
+	te := t.taskEntry
+	t.taskEntry = nil
+	t.rw.Unlock()
+	// NOTE: remove without the task lock, the timer checks the task under the spoke lock, see taskEntry.cancelled.
+	if te == nil {
+		return false
+	}
+	if te.remove() {
+		te.release()
+		return true
 	}
+	te.drop()
+	return false
 }
 
 // Delay return the delay duration.
@@ -88,10 +281,28 @@ func (t *Task) Delay() time.Duration {
 	return time.Duration(t.delay.Load())
 }
 
-// SetDelay set a new delay duration, the accuracy is milliseconds.
+// SetDelay set a new delay duration, the accuracy is milliseconds, the task becomes a relative-delay task.
 // NOTE: Only effect when re-add to `Timer`, It has no effect on the task being running!
 func (t *Task) SetDelay(d time.Duration) *Task {
 	t.delay.Store(int64(d))
+	t.atMs.Store(0)
+	return t
+}
+
+// At return the absolute wall clock time when the task expires,
+// the zero time indicate the task is a relative-delay task.
+func (t *Task) At() time.Time {
+	if ms := t.atMs.Load(); ms != 0 {
+		return time.UnixMilli(ms)
+	}
+	return time.Time{}
+}
+
+// SetAt set the absolute wall clock time when the task expires, the accuracy is milliseconds,
+// the task becomes an absolute-time task.
+// NOTE: Only effect when re-add to `Timer`, It has no effect on the task being running!
+func (t *Task) SetAt(at time.Time) *Task {
+	t.atMs.Store(at.UnixMilli())
 	return t
 }
 
@@ -131,13 +342,31 @@ func (t *Task) ExpiryAt() time.Time {
 // setBelongTo set the task belongs to the task entry.
 func (t *Task) setBelongTo(te *taskEntry) {
 	t.rw.Lock()
-	defer t.rw.Unlock()
 	// if this task already belong to an existing task entry,
 	// we should remove such an entry first.
-	if t.taskEntry != nil && t.taskEntry != te {
-		t.taskEntry.remove()
-	}
+	old := t.taskEntry
 	t.taskEntry = te
+	t.rw.Unlock()
+	// NOTE: remove without the task lock, the timer checks the task under the spoke lock, see taskEntry.cancelled.
+	if old != nil && old != te {
+		if old.remove() {
+			old.release()
+		} else {
+			old.drop()
+		}
+	}
+}
+
+// unsetBelongTo the task no longer belongs to the task entry, if it still belongs to.
+// It returns false if the task doesn't belong to the task entry.
+func (t *Task) unsetBelongTo(te *taskEntry) bool {
+	t.rw.Lock()
+	defer t.rw.Unlock()
+	if t.taskEntry == te {
+		t.taskEntry = nil
+		return true
+	}
+	return false
 }
 
 func (t *Task) isBelongTo(te *taskEntry) bool {
@@ -1,10 +1,13 @@
 package timer
 
 import (
+	"sync"
 	"sync/atomic"
-	"time"
 )
 
+// taskEntryPool reuses the task entries, so that rescheduling a task allocates nothing.
+var taskEntryPool = sync.Pool{New: func() any { return new(taskEntry) }}
+
 // taskEntry is an element of a linked list, hold the task instance.
 type taskEntry struct {
 	// next and previous pointers in the doubly-linked list of elements.
@@ -16,28 +19,75 @@ type taskEntry struct {
 	next         *taskEntry
 	list         atomic.Pointer[Spoke] // The list to which this element belongs.
 	expirationMs int64                 // expiration time, absolute time(immutable after first initialization), Units: ms
+	seq          uint64                // the sequence of adding, orders the task entries with the same expiration.
+	jitterMs     int64                 // the random delay included in the expiration, unit is milliseconds.
+	drops        atomic.Int32          // the number of holders dropped the cancelled task entry, see drop.
 	task         *Task                 // the task instance.
 }
 
-func newTaskEntry(task *Task) *taskEntry {
-	te := &taskEntry{
-		task:         task,
-		expirationMs: int64(task.Delay()/time.Millisecond) + time.Now().UnixMilli(),
-	}
+// newTaskEntry get a task entry from the pool, and the task belongs to it.
+func newTaskEntry(task *Task, expirationMs int64) *taskEntry {
+	te := acquireTaskEntry(task, expirationMs)
 	task.setBelongTo(te)
 	return te
 }
 
+// acquireTaskEntry get a task entry from the pool.
+func acquireTaskEntry(task *Task, expirationMs int64) *taskEntry {
+	te := taskEntryPool.Get().(*taskEntry)
+	te.task = task
+	te.expirationMs = expirationMs
+	return te
+}
+
+// release the task entry to the pool.
+// NOTE: only the owner can release the task entry, who removes it from the list (see remove),
+// or never adds it to the list, and the task must not belong to it,
+// so no one else holds the task entry, `Task.isBelongTo` can't be fooled by a reused one.
+func (te *taskEntry) release() {
+	te.prev = nil
+	te.next = nil
+	te.expirationMs = 0
+	te.seq = 0
+	te.jitterMs = 0
+	te.drops.Store(0)
+	te.task = nil
+	taskEntryPool.Put(te)
+}
+
+// drop the cancelled task entry, the later one of the two holders releases it.
+// the task entry cancelled but not removed by the canceller, is held by the timer too,
+// which drops it once it finds the task entry cancelled, see finish.
+func (te *taskEntry) drop() {
+	if te.drops.Add(1) == 2 {
+		te.release()
+	}
+}
+
+// finish the task entry fired or cancelled, which is held by the timer.
+func (te *taskEntry) finish() {
+	if te.task.unsetBelongTo(te) {
+		te.release()
+	} else {
+		te.drop()
+	}
+}
+
 // ExpirationMs return the expiration milliseconds.
 func (te *taskEntry) ExpirationMs() int64 { return te.expirationMs }
 
-func (te *taskEntry) remove() {
+// remove the task entry from the list, it returns true if the task entry is removed by this call,
+// then the caller owns the task entry.
+func (te *taskEntry) remove() bool {
 	// If remove is called when another thread is moving the entry from a task entry list to another,
 	// this may fail to remove the entry due to the change of value of list. Thus, we retry until the list becomes null.
 	// In a rare case, this thread sees null and exits the loop, but the other thread insert the entry to another list later.
 	for currentList := te.list.Load(); currentList != nil; currentList = te.list.Load() {
-		currentList.Remove(te)
+		if currentList.Remove(te) {
+			return true
+		}
 	}
+	return false
 }
 
 func (te *taskEntry) cancelled() bool {
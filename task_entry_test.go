@@ -0,0 +1,133 @@
+package timer
+
+import (
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TaskEntry_Reschedule_Allocs(t *testing.T) {
+	tm := NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	task := NewTask(time.Hour)
+	require.NoError(t, tm.AddTask(task))
+	// warm up the pool and the spokes.
+	for range 10 {
+		require.NoError(t, tm.AddTask(task))
+	}
+	require.Zero(t, testing.AllocsPerRun(1000, func() { _ = tm.AddTask(task) }))
+	require.Zero(t, testing.AllocsPerRun(1000, func() {
+		task.Cancel()
+		_ = tm.AddTask(task)
+	}))
+	require.True(t, task.Activated())
+	require.Equal(t, int64(1), tm.TaskCounter())
+}
+
+func Test_TaskEntry_Expire_Readd_Allocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the task entries are not always reused with the race detector")
+	}
+	// drive the timer as the advance goroutine does, without the allocations of the delay queue waiting.
+	tm := NewTimer()
+	tm.closed = false
+	advance := func(task *Task) {
+		for task.Activated() {
+			spoke, exist := tm.delayQueue.Poll()
+			if !exist {
+				continue
+			}
+			tm.rw.Lock()
+			for ; exist; spoke, exist = tm.delayQueue.Poll() {
+				tm.wheel.advanceClock(spoke.GetExpiration())
+				spoke.Flush(tm.reinsertTaskEntry)
+			}
+			tm.rw.Unlock()
+			tm.dispatchExpired()
+		}
+	}
+
+	// the task expires, then is re-added, the task entry fired is reused.
+	var fired atomic.Int64
+	task := NewTaskFunc(time.Millisecond, func() { fired.Add(1) })
+	task.inline = true
+	// warm up the pool and the spokes.
+	for range 10 {
+		require.NoError(t, tm.AddTask(task))
+		advance(task)
+	}
+	require.Zero(t, testing.AllocsPerRun(100, func() {
+		_ = tm.AddTask(task)
+		advance(task)
+	}))
+	require.Equal(t, int64(111), fired.Load())
+	require.Zero(t, tm.TaskCounter())
+}
+
+func Test_TaskEntry_Reuse(t *testing.T) {
+	tm := NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	type counted struct {
+		task *Task
+		adds atomic.Int64
+		runs atomic.Int64
+	}
+	tasks := make([]*counted, 64)
+	for i := range tasks {
+		c := &counted{}
+		c.task = NewTaskFunc(0, func() { c.runs.Add(1) })
+		tasks[i] = c
+	}
+
+	// add, cancel and fire the tasks concurrently, the task entries are reused in between.
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := rand.New(rand.NewPCG(uint64(g), 0))
+			for range 2000 {
+				c := tasks[r.IntN(len(tasks))]
+				switch r.IntN(3) {
+				case 0:
+					c.task.Cancel()
+				default:
+					c.adds.Add(1)
+					_ = tm.AddTask(c.task.SetDelay(time.Duration(r.IntN(3)) * time.Millisecond))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	for _, c := range tasks {
+		c.task.Cancel()
+	}
+	time.Sleep(20 * time.Millisecond)
+	require.Zero(t, tm.TaskCounter())
+	for _, c := range tasks {
+		// a task runs at most once per add.
+		require.LessOrEqual(t, c.runs.Load(), c.adds.Load())
+	}
+}
+
+func Benchmark_Timer_Reschedule(b *testing.B) {
+	tm := NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	task := NewTask(time.Hour)
+	_ = tm.AddTask(task)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = tm.AddTask(task)
+	}
+}
@@ -1,6 +1,8 @@
 package timer
 
 import (
+	"context"
+	"errors"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -53,6 +55,54 @@ func Test_Task_RecoverPanic(t *testing.T) {
 	require.NotPanics(t, task.Run)
 }
 
+func Test_Task_ContextJob(t *testing.T) {
+	errJob := errors.New("job error")
+	var gotErr error
+	task := NewTaskContextFunc(time.Millisecond, func(ctx context.Context) error {
+		_, ok := ctx.Deadline()
+		require.False(t, ok)
+		return errJob
+	}).WithErrorHandler(func(_ *Task, err error) { gotErr = err })
+	task.Run()
+	require.ErrorIs(t, gotErr, errJob)
+
+	// default error handler
+	require.NotPanics(t, NewTaskContextFunc(time.Millisecond, func(context.Context) error { return errJob }).Run)
+	// run as a job
+	require.NoError(t, JobContextFunc(func(context.Context) error { return nil }).RunContext(context.Background()))
+	JobContextFunc(func(context.Context) error { return nil }).Run()
+}
+
+func Test_Task_Timeout(t *testing.T) {
+	var gotErr error
+	onError := func(_ *Task, err error) { gotErr = err }
+
+	task := NewTaskContextFunc(time.Millisecond, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}).WithTimeout(10 * time.Millisecond).WithErrorHandler(onError)
+	require.Equal(t, 10*time.Millisecond, task.Timeout())
+	start := time.Now()
+	task.Run()
+	require.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+	require.ErrorIs(t, gotErr, ErrJobTimeout)
+
+	// finished in time
+	gotErr = nil
+	task = NewTaskContextFunc(time.Millisecond, func(context.Context) error { return nil }).
+		WithTimeout(time.Second).
+		WithErrorHandler(onError)
+	task.Run()
+	require.NoError(t, gotErr)
+
+	// the job not aware of the context, reported after it returns.
+	task = NewTaskFunc(time.Millisecond, func() { time.Sleep(20 * time.Millisecond) }).
+		WithTimeout(10 * time.Millisecond).
+		WithErrorHandler(onError)
+	task.Run()
+	require.ErrorIs(t, gotErr, ErrJobTimeout)
+}
+
 func Test_Task_Activated(t *testing.T) {
 	tm := NewTimer()
 	tm.Start()
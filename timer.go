@@ -2,6 +2,7 @@ package timer
 
 import (
 	"errors"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -69,31 +70,51 @@ func WithGoPool(p GoPool) Option {
 
 // Timer is a timer
 type Timer struct {
-	tickMs      int64                          // basic time span, unit is milliseconds.
-	wheelSize   int                            // wheel size, the power of 2
-	wheelMask   int                            // wheel mask
-	taskCounter atomic.Int64                   // the total number of tasks.
-	delayQueue  *delayqueue.DelayQueue[*Spoke] // delay queue, the priority queue use spoke's expiration time as `cmp`.
-	goPool      GoPool                         // goroutine pool
-	waitGroup   sync.WaitGroup                 // ensure the goroutine has finished.
-	rw          sync.RWMutex                   // protects following fields.
-	wheel       *TimingWheel                   // timing wheel, concurrent add task(read-lock) and advance clock only one(write-lock).
-	quit        chan struct{}                  // of chan struct{}, created when first start.
-	closed      bool                           // true if closed.
+	tickMs          int64                          // basic time span, unit is milliseconds.
+	wheelSize       int                            // wheel size, the power of 2
+	wheelMask       int                            // wheel mask
+	taskCounter     atomic.Int64                   // the total number of tasks.
+	cascades        atomic.Int64                   // the total number of task entries re-inserted into the lower level wheel.
+	wakeups         atomic.Int64                   // the total number of wakeups of the delay queue.
+	addSeq          atomic.Uint64                  // the sequence of adding task entries.
+	clock           *clock                         // the time base, captured at creation.
+	clockJumps      atomic.Int64                   // the total number of wall clock jumps detected.
+	clockJumpPolicy ClockJumpPolicy                // the policy of the absolute-time tasks when the wall clock jumps.
+	clockWatch      *Task                          // the task checks the wall clock jumps periodically, while there are absolute-time tasks.
+	delayQueue      *delayqueue.DelayQueue[*Spoke] // delay queue, the priority queue use spoke's expiration time as `cmp`.
+	goPool          GoPool                         // goroutine pool
+	jobs            jobLimiter                     // limits the number of jobs running at once.
+	lanes           laneExecutor                   // runs the jobs sharing a lane sequentially.
+	jitter          jitterSource                   // generates the random delay of the tasks.
+	misfire         misfireHandling                // the default misfire handling of the tasks.
+	expired         []*taskEntry                   // the task entries expired in the current advance batch, only accessed by the advance goroutine.
+	waitGroup       sync.WaitGroup                 // ensure the goroutine has finished.
+	lifecycle       sync.Mutex                     // serializes Start and Stop.
+	absoluteMu      sync.Mutex                     // protects absoluteTasks.
+	absoluteTasks   map[*Task]struct{}             // the absolute-time tasks may be pending.
+	rw              sync.RWMutex                   // protects following fields.
+	wheel           *TimingWheel                   // timing wheel, concurrent add task(read-lock) and advance clock only one(write-lock).
+	quit            chan struct{}                  // of chan struct{}, created when first start.
+	closed          bool                           // true if closed.
 }
 
 // NewTimer new timer instance. default tick is 1 milliseconds, wheel size is 512.
 func NewTimer(opts ...Option) *Timer {
 	t := &Timer{
-		tickMs:      DefaultTickMs,
-		wheelSize:   DefaultWheelSize,
-		wheelMask:   DefaultWheelSize - 1,
-		taskCounter: atomic.Int64{},
-		delayQueue:  delayqueue.NewDelayQueue(CompareSpoke),
-		goPool:      goroutinePool,
-		quit:        nil,
-		closed:      true,
+		tickMs:          DefaultTickMs,
+		wheelSize:       DefaultWheelSize,
+		wheelMask:       DefaultWheelSize - 1,
+		taskCounter:     atomic.Int64{},
+		clock:           newClock(),
+		clockJumpPolicy: ClockJumpPolicy_Reevaluate,
+		delayQueue:      delayqueue.NewDelayQueue(CompareSpoke),
+		goPool:          goroutinePool,
+		absoluteTasks:   make(map[*Task]struct{}),
+		quit:            nil,
+		closed:          true,
 	}
+	t.clockWatch = NewTaskFunc(time.Second, t.checkClock)
+	t.clockWatch.internal = true
 	for _, opt := range opts {
 		opt(t)
 	}
@@ -106,7 +127,7 @@ func NewTimer(opts ...Option) *Timer {
 	if t.goPool == nil {
 		t.goPool = goroutinePool
 	}
-	t.wheel = newTimingWheel(t, t.tickMs, time.Now().UnixMilli())
+	t.wheel = newTimingWheel(t, t.tickMs, t.clock.nowMs())
 	return t
 }
 
@@ -122,6 +143,14 @@ func (t *Timer) WheelMask() int { return t.wheelMask }
 // TaskCounter return the total number of tasks.
 func (t *Timer) TaskCounter() int64 { return t.taskCounter.Load() }
 
+// CascadeCounter return the total number of task entries which re-inserted into the lower level wheel,
+// when the spoke of the higher level wheel expired.
+func (t *Timer) CascadeCounter() int64 { return t.cascades.Load() }
+
+// WakeupCounter return the total number of wakeups of the delay queue, each wakeup advances
+// the wheel once, see Task.WithTolerance to reduce it.
+func (t *Timer) WakeupCounter() int64 { return t.wakeups.Load() }
+
 // AfterFunc adds a function to the timer.
 func (t *Timer) AfterFunc(d time.Duration, f func()) (*Task, error) {
 	task := NewTask(d).WithJobFunc(f)
@@ -132,17 +161,134 @@ func (t *Timer) AfterFunc(d time.Duration, f func()) (*Task, error) {
 	return task, nil
 }
 
+// AtFunc adds a function to the timer, which is called at the absolute wall clock time.
+func (t *Timer) AtFunc(at time.Time, f func()) (*Task, error) {
+	task := NewTaskAt(at).WithJobFunc(f)
+	err := t.AddTask(task)
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
 // AddTask adds a task to the timer.
+// The relative-delay task is based on the monotonic clock, the absolute-time task is
+// based on the wall clock, see WithClockJumpPolicy.
 func (t *Timer) AddTask(task *Task) error {
 	t.rw.RLock()
 	defer t.rw.RUnlock()
 	if t.closed {
 		return ErrClosed
 	}
-	t.addTaskEntry(newTaskEntry(task))
+	if task.atMs.Load() != 0 {
+		t.watchAbsoluteTask(task)
+	}
+	t.addTaskEntry(t.newTaskEntry(task, t.clock.nowMs()))
+	return nil
+}
+
+// AddTasks adds the tasks to the timer in a batch, it acquires the lock once,
+// and inserts the consecutive task entries of the same spoke together, locking the spoke once.
+// NOTE: only the consecutive ones are grouped, the unsorted tasks lock the spoke once per task,
+// as AddTask does, sort the tasks by expiry to share the spokes most.
+func (t *Timer) AddTasks(tasks []*Task) error {
+	t.rw.RLock()
+	defer t.rw.RUnlock()
+	if t.closed {
+		return ErrClosed
+	}
+	// NOTE: the tasks in the batch are added at the same time.
+	nowMs := t.clock.nowMs()
+	batch := spokeBatch{apply: func(spoke *Spoke, tes []*taskEntry) {
+		// the task added more than once belongs to the last task entry.
+		spoke.addBatch(slices.DeleteFunc(tes, func(te *taskEntry) bool {
+			if te.cancelled() {
+				te.drop()
+				return true
+			}
+			return false
+		}))
+	}}
+	var expired []*taskEntry
+	for _, task := range tasks {
+		if task.atMs.Load() != 0 {
+			t.watchAbsoluteTask(task)
+		}
+		te := t.newTaskEntry(task, nowMs)
+		switch spoke, result := t.wheel.locate(te); result {
+		case Result_Success:
+			batch.add(spoke, te)
+		case Result_Canceled:
+			te.drop()
+		case Result_AlreadyExpired:
+			expired = append(expired, te)
+		}
+	}
+	batch.flush()
+	for _, te := range expired {
+		// the task added more than once, or cancelled since.
+		if !te.cancelled() {
+			if te.task.inline {
+				te.task.Run()
+			} else {
+				t.fire(te)
+			}
+		}
+		te.finish()
+	}
 	return nil
 }
 
+// CancelTasks cancel the tasks in a batch, it acquires the lock once,
+// and removes the consecutive task entries of the same spoke together, locking the spoke once.
+// NOTE: only the consecutive ones are grouped, same as AddTasks.
+func (t *Timer) CancelTasks(tasks []*Task) {
+	batch := spokeBatch{apply: func(spoke *Spoke, tes []*taskEntry) {
+		// NOTE: the task entries can't be moved to another spoke under `Timer.rw` lock,
+		// the ones not removed are flushed by the timer.
+		n := spoke.removeBatch(tes)
+		for _, te := range tes[:n] {
+			te.release()
+		}
+		for _, te := range tes[n:] {
+			te.drop()
+		}
+	}}
+	t.rw.RLock()
+	for _, task := range tasks {
+		task.rw.Lock()
+		// NOTE: the task entry is cancelled once the task doesn't belong to it, even if it is not removed yet.
+		te := task.taskEntry
+		task.taskEntry = nil
+		task.rw.Unlock()
+		// NOTE: remove without the task lock, see Task.cancel.
+		if te != nil {
+			if spoke := te.list.Load(); spoke != nil {
+				batch.add(spoke, te)
+			} else {
+				te.drop()
+			}
+		}
+	}
+	batch.flush()
+	t.rw.RUnlock()
+	for _, task := range tasks {
+		if g := task.group.Load(); g != nil {
+			g.remove(task)
+		}
+	}
+}
+
+// Fire the pending task immediately, its job is dispatched through the timer as if it expired.
+// It returns false if the task is not pending, or is being fired by the timer.
+func (t *Timer) Fire(task *Task) bool {
+	if !task.cancel() {
+		return false
+	}
+	t.dispatch(task, task)
+	return true
+}
+
 // AddDerefTask adds a task from DerefTask to the timer.
 func (t *Timer) AddDerefTask(tc DerefTask) error {
 	return t.AddTask(tc.DerefTask())
@@ -157,39 +303,63 @@ func (t *Timer) Started() bool {
 
 // Start the timer.
 func (t *Timer) Start() {
+	t.lifecycle.Lock()
+	defer t.lifecycle.Unlock()
 	t.rw.Lock()
 	defer t.rw.Unlock()
 	if t.closed {
 		t.closed = false
 		t.quit = make(chan struct{})
 		t.waitGroup.Add(1)
-		go func() {
+		go func(quit <-chan struct{}) {
 			defer t.waitGroup.Done()
 			for {
-				spoke, exit := t.delayQueue.Take(t.quit)
+				spoke, exit := t.delayQueue.Take(quit)
 				if exit {
 					break
 				}
+				t.wakeups.Add(1)
 				t.rw.Lock()
 				for exist := true; exist; spoke, exist = t.delayQueue.Poll() {
 					t.wheel.advanceClock(spoke.GetExpiration())
-					spoke.Flush(t.addTaskEntry) // reinsert task entry to the timer
+					spoke.Flush(t.reinsertTaskEntry) // reinsert task entry to the timer
 				}
 				t.rw.Unlock()
+				t.dispatchExpired()
 			}
-		}()
+		}(t.quit)
 	}
 }
 
 // Stop the timer, graceful shutdown waiting the goroutine until it's stopped.
 func (t *Timer) Stop() {
+	t.lifecycle.Lock()
+	defer t.lifecycle.Unlock()
 	t.rw.Lock()
-	defer t.rw.Unlock()
-	if !t.closed {
-		close(t.quit)
-		t.waitGroup.Wait() // Ensure the goroutine has finished
-		t.closed = true
+	if t.closed {
+		t.rw.Unlock()
+		return
+	}
+	close(t.quit)
+	t.closed = true
+	// NOTE: wait without `Timer.rw` lock, the goroutine may be advancing the clock,
+	// which needs the lock.
+	t.rw.Unlock()
+	t.waitGroup.Wait() // Ensure the goroutine has finished
+}
+
+// newTaskEntry new task entry of the task added at nowMs, the jitter is drawn once here,
+// and kept when the task entry is rescheduled, see rescheduleActiveTask.
+func (t *Timer) newTaskEntry(task *Task, nowMs int64) *taskEntry {
+	expirationMs := t.clock.expirationMs(task, nowMs)
+	var jitterMs int64
+	if delayMs := expirationMs - nowMs; delayMs >= 0 && !task.internal {
+		jitterMs = t.jitter.jitterMs(task, delayMs)
 	}
+	te := newTaskEntry(task, expirationMs+jitterMs)
+	te.jitterMs = jitterMs
+	te.seq = t.addSeq.Add(1)
+	return te
 }
 
 func (t *Timer) addToDelayQueue(spoke *Spoke) {
@@ -197,11 +367,40 @@ func (t *Timer) addToDelayQueue(spoke *Spoke) {
 }
 
 // NOTE: should be call when `Timer.rw` lock.
-func (t *Timer) addTaskEntry(te *taskEntry) {
+func (t *Timer) addTaskEntry(te *taskEntry) Result {
 	// if success, we do not need deal the task entry, because it has be added to the timing wheel.
 	// if cancelled cancelled, we ignore the task entry.
 	// if already expired, we run the task job.
-	if t.wheel.add(te) == Result_AlreadyExpired {
-		t.goPool.Go(te.task.Run)
+	result := t.wheel.add(te)
+	switch result {
+	case Result_Canceled:
+		te.drop()
+	case Result_AlreadyExpired:
+		if te.task.inline {
+			te.task.Run()
+		} else {
+			t.fire(te)
+		}
+		te.finish()
+	}
+	return result
+}
+
+// reinsertTaskEntry reinsert the task entry flushed from an expired spoke,
+// the expired one is collected into the advance batch, see dispatchExpired.
+// NOTE: should be call when `Timer.rw` lock.
+func (t *Timer) reinsertTaskEntry(te *taskEntry) {
+	switch t.wheel.add(te) {
+	case Result_Success:
+		t.cascades.Add(1)
+	case Result_Canceled:
+		te.drop()
+	case Result_AlreadyExpired:
+		if te.task.inline {
+			te.task.Run()
+			te.finish()
+		} else {
+			t.expired = append(t.expired, te)
+		}
 	}
 }
@@ -1,6 +1,8 @@
 package timer
 
 import (
+	"io"
+	"iter"
 	"time"
 
 	"github.com/panjf2000/ants/v2"
@@ -28,12 +30,46 @@ func TaskCounter() int64 { return defaultTimer.TaskCounter() }
 // AfterFunc adds a function to the timer.
 func AfterFunc(d time.Duration, f func()) (*Task, error) { return defaultTimer.AfterFunc(d, f) }
 
+// AtFunc adds a function to the timer, which is called at the absolute wall clock time.
+func AtFunc(at time.Time, f func()) (*Task, error) { return defaultTimer.AtFunc(at, f) }
+
+// NewStdTimer creates a new StdTimer, same as time.NewTimer.
+func NewStdTimer(d time.Duration) *StdTimer { return defaultTimer.NewStdTimer(d) }
+
+// NewStdTicker returns a new StdTicker, same as time.NewTicker.
+func NewStdTicker(d time.Duration) *StdTicker { return defaultTimer.NewStdTicker(d) }
+
+// StdAfterFunc waits for the duration to elapse and then calls f, same as time.AfterFunc.
+func StdAfterFunc(d time.Duration, f func()) *StdTimer { return defaultTimer.StdAfterFunc(d, f) }
+
+// After waits for the duration to elapse and then sends the current time on the returned channel,
+// same as time.After.
+func After(d time.Duration) <-chan time.Time { return defaultTimer.NewStdTimer(d).C }
+
+// Tick is a convenience wrapper for NewStdTicker providing access to the ticking channel only,
+// it returns nil if d <= 0, same as time.Tick.
+func Tick(d time.Duration) <-chan time.Time {
+	if d <= 0 {
+		return nil
+	}
+	return defaultTimer.NewStdTicker(d).C
+}
+
 // AddTask adds a task to the timer.
 func AddTask(task *Task) error { return defaultTimer.AddTask(task) }
 
 // AddDerefTask adds a task from DerefTask to the timer.
 func AddDerefTask(task DerefTask) error { return defaultTimer.AddDerefTask(task) }
 
+// Pending returns an iterator over the tasks pending in the timer, in no particular order.
+func Pending() iter.Seq[*Task] { return defaultTimer.Pending() }
+
+// NextExpiry return the milliseconds as a Unix time when the next spoke will be expired.
+func NextExpiry() int64 { return defaultTimer.NextExpiry() }
+
+// Dump writes a human-readable description of the timing wheel structure to w.
+func Dump(w io.Writer) error { return defaultTimer.Dump(w) }
+
 // Started have started or not.
 func Started() bool { return defaultTimer.Started() }
 
@@ -0,0 +1,186 @@
+package timer
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"time"
+)
+
+// WheelLevel is a snapshot of a level of the hierarchical timing wheel.
+type WheelLevel struct {
+	Level       int         // level of the wheel, 0 is the lowest.
+	TickMs      int64       // basic time span of the wheel, unit is milliseconds.
+	Interval    int64       // the overall time span of the wheel, tickMs * wheelSize.
+	CurrentTime int64       // dial pointer of the wheel, absolute time, unit is milliseconds.
+	Spokes      []SpokeInfo // non-empty spokes only.
+}
+
+// SpokeInfo is a snapshot of a non-empty spoke.
+type SpokeInfo struct {
+	Index      int   // index of the spoke in the wheel.
+	Expiration int64 // the expiration time, absolute time, unit is milliseconds, -1 means not scheduled.
+	Tasks      int   // the number of tasks in the spoke.
+}
+
+// Pending returns an iterator over the tasks pending in the timer, in no particular order.
+// It iterates over a snapshot taken when the iteration starts.
+func (t *Timer) Pending() iter.Seq[*Task] {
+	return func(yield func(*Task) bool) {
+		for _, e := range t.pendingEntries() {
+			if !yield(e.task) {
+				return
+			}
+		}
+	}
+}
+
+// PendingByExpiry returns an iterator over the tasks pending in the timer, ordered by expiration time.
+// It iterates over a snapshot taken when the iteration starts.
+func (t *Timer) PendingByExpiry() iter.Seq[*Task] {
+	return func(yield func(*Task) bool) {
+		entries := t.pendingEntries()
+		slices.SortStableFunc(entries, func(a, b pendingEntry) int {
+			return cmp.Compare(a.expirationMs, b.expirationMs)
+		})
+		for _, e := range entries {
+			if !yield(e.task) {
+				return
+			}
+		}
+	}
+}
+
+// NextExpiry return the milliseconds as a Unix time when the next spoke will be expired.
+// the value -1 indicate no task pending.
+// NOTE: the spoke with the internal tasks only is skipped, same as Pending.
+func (t *Timer) NextExpiry() int64 {
+	spoke, exist := t.delayQueue.Peek()
+	if !exist {
+		return -1
+	}
+	if hasVisibleTask(spoke) {
+		return spoke.GetExpiration()
+	}
+	t.rw.RLock()
+	defer t.rw.RUnlock()
+	next := int64(-1)
+	for tw := t.wheel; tw != nil; tw = tw.overflowWheel.Load() {
+		for _, spoke := range tw.spokes {
+			if expiration := spoke.GetExpiration(); expiration >= 0 &&
+				(next < 0 || expiration < next) &&
+				hasVisibleTask(spoke) {
+				next = expiration
+			}
+		}
+	}
+	return next
+}
+
+// hasVisibleTask report whether the spoke has any task not internal.
+func hasVisibleTask(spoke *Spoke) bool {
+	visible := false
+	spoke.rangeTaskEntry(func(te *taskEntry) bool {
+		visible = !te.task.internal
+		return !visible
+	})
+	return visible
+}
+
+// Levels returns a snapshot of each level of the hierarchical timing wheel, from the lowest level.
+func (t *Timer) Levels() []WheelLevel {
+	t.rw.RLock()
+	defer t.rw.RUnlock()
+	levels := make([]WheelLevel, 0, 4)
+	for tw, level := t.wheel, 0; tw != nil; tw, level = tw.overflowWheel.Load(), level+1 {
+		wl := WheelLevel{
+			Level:       level,
+			TickMs:      tw.tickMs,
+			Interval:    tw.interval,
+			CurrentTime: tw.currentTime,
+		}
+		for i, spoke := range tw.spokes {
+			n := 0
+			spoke.rangeTaskEntry(func(te *taskEntry) bool {
+				if !te.task.internal {
+					n++
+				}
+				return true
+			})
+			if n > 0 {
+				wl.Spokes = append(wl.Spokes, SpokeInfo{
+					Index:      i,
+					Expiration: spoke.GetExpiration(),
+					Tasks:      n,
+				})
+			}
+		}
+		levels = append(levels, wl)
+	}
+	return levels
+}
+
+// Dump writes a human-readable description of the timing wheel structure to w,
+// each wheel level's tickMs, interval, currentTime, and the non-empty spokes with their expiration.
+func (t *Timer) Dump(w io.Writer) error {
+	levels := t.Levels()
+	ew := &errWriter{w: w}
+	ew.printf("timer: tickMs=%d wheelSize=%d tasks=%d levels=%d nextExpiry=%s\n",
+		t.TickMs(), t.WheelSize(), t.TaskCounter(), len(levels), formatMs(t.NextExpiry()))
+	for _, wl := range levels {
+		ew.printf("level %d: tickMs=%d interval=%d currentTime=%s spokes=%d/%d\n",
+			wl.Level, wl.TickMs, wl.Interval, formatMs(wl.CurrentTime), len(wl.Spokes), t.WheelSize())
+		for _, sp := range wl.Spokes {
+			ew.printf("  spoke[%d]: expiration=%s tasks=%d\n", sp.Index, formatMs(sp.Expiration), sp.Tasks)
+		}
+	}
+	return ew.err
+}
+
+// pendingEntry a snapshot of the task entry pending in the timer.
+type pendingEntry struct {
+	te           *taskEntry // only for identity, the task entry may be reused once out of the spoke lock.
+	task         *Task
+	expirationMs int64
+}
+
+// pendingEntries returns a snapshot of the task entries pending in the timer, except the internal tasks.
+func (t *Timer) pendingEntries() []pendingEntry {
+	t.rw.RLock()
+	defer t.rw.RUnlock()
+	entries := make([]pendingEntry, 0, t.TaskCounter())
+	for tw := t.wheel; tw != nil; tw = tw.overflowWheel.Load() {
+		for _, spoke := range tw.spokes {
+			spoke.rangeTaskEntry(func(te *taskEntry) bool {
+				if !te.task.internal {
+					entries = append(entries, pendingEntry{te: te, task: te.task, expirationMs: te.expirationMs})
+				}
+				return true
+			})
+		}
+	}
+	// NOTE: check cancelled out of the spoke lock, `Task.Cancel` lock the task then the spoke.
+	return slices.DeleteFunc(entries, func(e pendingEntry) bool { return !e.task.isBelongTo(e.te) })
+}
+
+// formatMs format the milliseconds as a Unix time, -1 means none.
+func formatMs(ms int64) string {
+	if ms < 0 {
+		return "none"
+	}
+	return fmt.Sprintf("%d(%s)", ms, time.UnixMilli(ms).Format("2006-01-02T15:04:05.000Z07:00"))
+}
+
+// errWriter writes formatted text and remembers the first error.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) printf(format string, args ...any) {
+	if ew.err == nil {
+		_, ew.err = fmt.Fprintf(ew.w, format, args...)
+	}
+}
@@ -0,0 +1,102 @@
+package timer
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Timer_Inspect(t *testing.T) {
+	tm := NewTimer(WithWheelSize(16))
+	require.Equal(t, int64(-1), tm.NextExpiry())
+	require.Empty(t, slices.Collect(tm.Pending()))
+	require.Len(t, tm.Levels(), 1)
+
+	tm.Start()
+	defer tm.Stop()
+
+	delays := []time.Duration{
+		time.Second,
+		50 * time.Millisecond,
+		time.Minute,
+		10 * time.Millisecond,
+		500 * time.Millisecond,
+	}
+	tasks := make([]*Task, 0, len(delays))
+	for _, d := range delays {
+		task := NewTask(d)
+		require.NoError(t, tm.AddTask(task))
+		tasks = append(tasks, task)
+	}
+	canceled := NewTask(time.Hour)
+	require.NoError(t, tm.AddTask(canceled))
+	canceled.Cancel()
+
+	// unordered
+	pending := slices.Collect(tm.Pending())
+	require.ElementsMatch(t, tasks, pending)
+
+	// ordered by expiry
+	want := []*Task{tasks[3], tasks[1], tasks[4], tasks[0], tasks[2]}
+	require.Equal(t, want, slices.Collect(tm.PendingByExpiry()))
+	for task := range tm.PendingByExpiry() {
+		require.Equal(t, tasks[3], task)
+		break
+	}
+
+	// next expiry
+	next := tm.NextExpiry()
+	require.Greater(t, next, int64(0))
+	require.LessOrEqual(t, next, tasks[3].Expiry())
+
+	// levels
+	levels := tm.Levels()
+	require.Greater(t, len(levels), 1)
+	total := 0
+	for i, wl := range levels {
+		require.Equal(t, i, wl.Level)
+		require.Equal(t, wl.TickMs*int64(tm.WheelSize()), wl.Interval)
+		if i > 0 {
+			require.Equal(t, levels[i-1].Interval, wl.TickMs)
+		}
+		for _, sp := range wl.Spokes {
+			require.Greater(t, sp.Tasks, 0)
+			total += sp.Tasks
+		}
+	}
+	require.Equal(t, len(tasks), total)
+
+	// dump
+	buf := &bytes.Buffer{}
+	require.NoError(t, tm.Dump(buf))
+	out := buf.String()
+	require.Contains(t, out, "timer: tickMs=1 wheelSize=16 tasks=5")
+	require.Contains(t, out, "level 0: tickMs=1 interval=16")
+	require.Contains(t, out, "spoke[")
+	require.Equal(t, len(levels), strings.Count(out, "\nlevel "))
+
+	// expired tasks are not pending
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, []*Task{tasks[4], tasks[0], tasks[2]}, slices.Collect(tm.PendingByExpiry()))
+}
+
+func Test_Timer_NextExpiry_Internal(t *testing.T) {
+	tm := NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	// the clock watcher expires first, but it is not reported.
+	task, err := tm.AtFunc(time.Now().Add(time.Hour), func() {})
+	require.NoError(t, err)
+	require.True(t, tm.clockWatch.Activated())
+	// the spoke of the higher level wheel expires at the start of its time span.
+	require.Greater(t, tm.NextExpiry(), time.Now().Add(time.Minute).UnixMilli())
+
+	task.Cancel()
+	require.True(t, tm.clockWatch.Activated())
+	require.Equal(t, int64(-1), tm.NextExpiry())
+}
@@ -1,7 +1,12 @@
 package timer
 
 import (
+	"context"
 	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -59,6 +64,53 @@ func Test_Timer_Start_Stop_Restart(t *testing.T) {
 	require.True(t, tm.Started())
 }
 
+func Test_Timer_Stop_WhileAdvancing(t *testing.T) {
+	tm := NewTimer(WithWheelSize(16))
+	for i := 0; i < 20; i++ {
+		tm.Start()
+		for j := 0; j < 1000; j++ {
+			_, err := tm.AfterFunc(time.Duration(j%20)*time.Millisecond, func() {})
+			require.NoError(t, err)
+		}
+		time.Sleep(time.Duration(i%5) * time.Millisecond)
+
+		stopped := make(chan struct{})
+		go func() {
+			tm.Stop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(5 * time.Second):
+			t.Fatal("stop deadlock while the timer is advancing")
+		}
+		require.False(t, tm.Started())
+	}
+}
+
+func Test_Timer_CascadeCounter(t *testing.T) {
+	tm := NewTimer(WithWheelSize(64))
+	tm.Start()
+	defer tm.Stop()
+	require.Zero(t, tm.CascadeCounter())
+
+	// on the lowest level wheel, no cascade.
+	fired := make(chan struct{}, 2)
+	_, err := tm.AfterFunc(5*time.Millisecond, func() { fired <- struct{}{} })
+	require.NoError(t, err)
+	<-fired
+	require.Zero(t, tm.CascadeCounter())
+
+	// on the higher level wheel, cascade at least once.
+	// expires in the middle of the spoke of 64ms, so that the spoke flushed late still cascades it.
+	nowMs := tm.clock.nowMs()
+	delayMs := 128 + (48-(nowMs+128)%64+64)%64
+	_, err = tm.AfterFunc(time.Duration(delayMs)*time.Millisecond, func() { fired <- struct{}{} })
+	require.NoError(t, err)
+	<-fired
+	require.Greater(t, tm.CascadeCounter(), int64(0))
+}
+
 func ExampleTimer() {
 	tm := NewTimer()
 	tm.Start()
@@ -81,3 +133,237 @@ func ExampleTimer() {
 	// 200
 	// canceled then add again
 }
+
+func Test_Timer_Tolerance(t *testing.T) {
+	t.Run("slot", func(t *testing.T) {
+		tm := NewTimer(WithWheelSize(64))
+		tw := newTimingWheel(tm, 1, 1000)
+		require.Equal(t, int64(1010), tw.slot(1010, 0))
+		// the most aligned one.
+		require.Equal(t, int64(1016), tw.slot(1010, 10))
+		require.Equal(t, int64(1024), tw.slot(1010, 20))
+		// limited to the current time wheel.
+		require.Equal(t, int64(1056), tw.slot(1030, 100))
+		// prefer the already scheduled spoke.
+		tw.spokes[1012&tm.WheelMask()].SetExpiration(1012)
+		require.Equal(t, int64(1012), tw.slot(1010, 20))
+		require.Equal(t, int64(1024), tw.slot(1013, 20))
+	})
+
+	t.Run("fire", func(t *testing.T) {
+		tm := NewTimer()
+		tm.Start()
+		defer tm.Stop()
+
+		var wg sync.WaitGroup
+		var early atomic.Int64
+		for i := range 50 {
+			wg.Add(1)
+			delay := time.Duration(10+i) * time.Millisecond
+			start := time.Now()
+			task := NewTask(delay).WithTolerance(20 * time.Millisecond).WithJobFunc(func() {
+				defer wg.Done()
+				if time.Since(start) < delay-time.Millisecond {
+					early.Add(1)
+				}
+			})
+			require.Equal(t, 20*time.Millisecond, task.Tolerance())
+			require.NoError(t, tm.AddTask(task))
+		}
+		wg.Wait()
+		require.Zero(t, early.Load())
+		require.Less(t, tm.WakeupCounter(), int64(50))
+	})
+}
+
+// Benchmark_Timer_Tolerance reports the wakeups of the delay queue per 1000 tasks.
+func Benchmark_Timer_Tolerance(b *testing.B) {
+	for _, tolerance := range []time.Duration{0, 10 * time.Millisecond, 50 * time.Millisecond} {
+		b.Run(tolerance.String(), func(b *testing.B) {
+			tm := NewTimer()
+			tm.Start()
+			defer tm.Stop()
+
+			var wg sync.WaitGroup
+			for i := 0; i < b.N; i++ {
+				wg.Add(1000)
+				for j := range 1000 {
+					task := NewTask(time.Duration(j%100) * time.Millisecond).
+						WithTolerance(tolerance).
+						WithJobFunc(wg.Done)
+					_ = tm.AddTask(task)
+				}
+				wg.Wait()
+			}
+			b.ReportMetric(float64(tm.WakeupCounter())/float64(b.N), "wakeups/op")
+		})
+	}
+}
+
+func Test_Timer_AddTasks(t *testing.T) {
+	tm := NewTimer()
+	require.ErrorIs(t, tm.AddTasks([]*Task{NewTask(time.Millisecond)}), ErrClosed)
+	tm.Start()
+	defer tm.Stop()
+
+	var fired atomic.Int64
+	tasks := make([]*Task, 0, 1001)
+	for i := range 1000 {
+		tasks = append(tasks, NewTaskFunc(time.Duration(i%50)*time.Millisecond, func() { fired.Add(1) }))
+	}
+	// the task added more than once fires once.
+	tasks = append(tasks, tasks[999])
+	require.NoError(t, tm.AddTasks(tasks))
+	require.Eventually(t, func() bool { return fired.Load() == 1000 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	require.Equal(t, int64(1000), fired.Load())
+	require.Zero(t, tm.TaskCounter())
+}
+
+func Test_Timer_AddTasks_Expired(t *testing.T) {
+	tm := NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	// the expired task added more than once fires once.
+	var fired, firedInline atomic.Int64
+	task := NewTaskAt(time.Now().Add(-time.Second)).WithJobFunc(func() { fired.Add(1) })
+	inline := NewTaskAt(time.Now().Add(-time.Second)).WithJobFunc(func() { firedInline.Add(1) })
+	inline.inline = true
+	require.NoError(t, tm.AddTasks([]*Task{task, inline, task, inline}))
+	require.Eventually(t, func() bool { return fired.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	require.Equal(t, int64(1), fired.Load())
+	require.Equal(t, int64(1), firedInline.Load())
+	require.False(t, task.Activated())
+}
+
+func Test_Timer_CancelTasks(t *testing.T) {
+	tm := NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	g := tm.NewGroup("cancel")
+	tasks := make([]*Task, 0, 1000)
+	for i := range 1000 {
+		task := NewTaskFunc(time.Duration(i%50+10)*time.Second, func() { t.Error("cancelled task should not run") })
+		if i%2 == 0 {
+			require.NoError(t, g.AddTask(task))
+		}
+		tasks = append(tasks, task)
+	}
+	require.NoError(t, tm.AddTasks(tasks))
+	require.Equal(t, int64(1000), tm.TaskCounter())
+	require.Equal(t, 500, g.Len())
+
+	tm.CancelTasks(tasks)
+	require.Zero(t, tm.TaskCounter())
+	require.Zero(t, g.Len())
+	for _, task := range tasks {
+		require.False(t, task.Activated())
+	}
+	require.Empty(t, slices.Collect(tm.Pending()))
+}
+
+func newBenchmarkTasks(n int) []*Task {
+	tasks := make([]*Task, n)
+	for i := range tasks {
+		tasks[i] = NewTask(time.Duration(i%1000+1) * time.Second)
+	}
+	return tasks
+}
+
+func Benchmark_Timer_AddTask_Loop(b *testing.B) {
+	tm := NewTimer()
+	tm.Start()
+	defer tm.Stop()
+	tasks := newBenchmarkTasks(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, task := range tasks {
+			_ = tm.AddTask(task)
+		}
+	}
+}
+
+func Benchmark_Timer_AddTasks(b *testing.B) {
+	tm := NewTimer()
+	tm.Start()
+	defer tm.Stop()
+	tasks := newBenchmarkTasks(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = tm.AddTasks(tasks)
+	}
+}
+
+func Benchmark_Timer_AddTasks_Unsorted(b *testing.B) {
+	tm := NewTimer()
+	tm.Start()
+	defer tm.Stop()
+	tasks := newBenchmarkTasks(10000)
+	r := rand.New(rand.NewPCG(1, 2))
+	r.Shuffle(len(tasks), func(i, j int) { tasks[i], tasks[j] = tasks[j], tasks[i] })
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = tm.AddTasks(tasks)
+	}
+}
+
+func Benchmark_Timer_Cancel_Loop(b *testing.B) {
+	tm := NewTimer()
+	tm.Start()
+	defer tm.Stop()
+	tasks := newBenchmarkTasks(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		_ = tm.AddTasks(tasks)
+		b.StartTimer()
+		for _, task := range tasks {
+			task.Cancel()
+		}
+	}
+}
+
+func Benchmark_Timer_CancelTasks(b *testing.B) {
+	tm := NewTimer()
+	tm.Start()
+	defer tm.Stop()
+	tasks := newBenchmarkTasks(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		_ = tm.AddTasks(tasks)
+		b.StartTimer()
+		tm.CancelTasks(tasks)
+	}
+}
+
+func Test_Timer_Fire(t *testing.T) {
+	tm := NewTimer()
+	tm.Start()
+	defer tm.Stop()
+
+	g := tm.NewGroup("fire")
+	var fired atomic.Int64
+	task, err := g.AfterFunc(time.Hour, func() { fired.Add(1) })
+	require.NoError(t, err)
+	require.True(t, tm.Fire(task))
+	require.False(t, tm.Fire(task))
+	require.NoError(t, g.Wait(context.Background()))
+	require.Equal(t, int64(1), fired.Load())
+	require.False(t, task.Activated())
+
+	// racing with the expiration, the job runs once.
+	for range 100 {
+		fired.Store(0)
+		task, err = tm.AfterFunc(time.Millisecond, func() { fired.Add(1) })
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond)
+		tm.Fire(task)
+		require.Eventually(t, func() bool { return fired.Load() == 1 }, time.Second, time.Millisecond)
+		time.Sleep(2 * time.Millisecond)
+		require.Equal(t, int64(1), fired.Load())
+	}
+}
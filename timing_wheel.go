@@ -23,7 +23,7 @@ type TimingWheel struct {
 func newTimingWheel(t *Timer, tickMs int64, startMs int64) *TimingWheel {
 	spokes := make([]*Spoke, t.wheelSize)
 	for i := range spokes {
-		spokes[i] = NewSpoke(&t.taskCounter)
+		spokes[i] = newSpoke(&t.taskCounter, t.clock)
 	}
 	tw := &TimingWheel{
 		timer:       t,
@@ -38,19 +38,28 @@ func newTimingWheel(t *Timer, tickMs int64, startMs int64) *TimingWheel {
 // add to the timing wheel.
 // true: add success, false: canceled or already expired
 func (tw *TimingWheel) add(te *taskEntry) Result {
+	spoke, result := tw.locate(te)
+	if result == Result_Success {
+		spoke.Add(te)
+	}
+	return result
+}
+
+// locate the spoke of the task entry, and schedule the spoke, the task entry is not added.
+// NOTE: should be call when `Timer.rw` lock, the spoke can't be flushed before the task entry is added.
+func (tw *TimingWheel) locate(te *taskEntry) (*Spoke, Result) {
 	if te.cancelled() { // already cancelled
-		return Result_Canceled
+		return nil, Result_Canceled
 	}
 
 	expiration := te.ExpirationMs()
 	switch {
 	case expiration < tw.currentTime+tw.tickMs: // already expired
-		return Result_AlreadyExpired
+		return nil, Result_AlreadyExpired
 	case expiration < tw.currentTime+tw.interval: // on the current time wheel
-		// Put in its own spoke
-		virtualId := expiration / tw.tickMs
+		// Put in its own spoke, or a later one within the tolerance.
+		virtualId := tw.slot(expiration, te.task.tolerance.Milliseconds())
 		spoke := tw.spokes[int(virtualId)&tw.timer.WheelMask()]
-		spoke.Add(te)
 
 		// Set the spoke expiration time
 		// It safe, because only change when `Timer.rw` lock. @Spoke.Add @Spoke.Flush
@@ -63,15 +72,38 @@ func (tw *TimingWheel) add(te *taskEntry) Result {
 			// be enqueued multiple times.
 			tw.timer.addToDelayQueue(spoke)
 		}
-		return Result_Success
+		return spoke, Result_Success
 	default: // not on the current wheel, add a high-level time wheel.
 		overflowWheel := tw.overflowWheel.Load()
 		if overflowWheel == nil {
 			tw.overflowWheel.CompareAndSwap(nil, newTimingWheel(tw.timer, tw.interval, tw.currentTime))
 			overflowWheel = tw.overflowWheel.Load()
 		}
-		return overflowWheel.add(te)
+		return overflowWheel.locate(te)
+	}
+}
+
+// slot returns the virtual id of the spoke for the expiration, with the tolerance,
+// it prefers an already scheduled spoke, then the most aligned one, so that the tasks
+// with tolerance share the spokes and reduce the wakeups of the delay queue.
+// NOTE: the expiration must be on the current time wheel.
+func (tw *TimingWheel) slot(expiration, toleranceMs int64) int64 {
+	lo := expiration / tw.tickMs
+	hi := min((expiration+toleranceMs)/tw.tickMs, (tw.currentTime+tw.interval)/tw.tickMs-1)
+	if hi <= lo {
+		return lo
+	}
+	for virtualId := lo; virtualId <= hi; virtualId++ {
+		spoke := tw.spokes[int(virtualId)&tw.timer.WheelMask()]
+		if spoke.GetExpiration() == virtualId*tw.tickMs {
+			return virtualId
+		}
+	}
+	mask := int64(1)
+	for mask <= hi && hi&^(mask<<1-1) >= lo {
+		mask <<= 1
 	}
+	return hi &^ (mask - 1)
 }
 
 func (tw *TimingWheel) advanceClock(timeMs int64) {
@@ -0,0 +1,256 @@
+// Package ttlcache provides an in-memory cache with per-entry time-to-live,
+// the expirations are driven by a timer.Timer instead of a janitor goroutine scanning the map.
+package ttlcache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/thinkgos/timer"
+)
+
+// NoExpiration the entry never expires.
+const NoExpiration time.Duration = 0
+
+// EvictReason the reason an entry is evicted.
+type EvictReason int
+
+const (
+	EvictReason_Expired  EvictReason = iota // the entry is expired.
+	EvictReason_Capacity                    // the cache is full, the least recently used entry is evicted.
+	EvictReason_Deleted                     // the entry is deleted or replaced.
+)
+
+// String implements fmt.Stringer.
+func (r EvictReason) String() string {
+	switch r {
+	case EvictReason_Expired:
+		return "expired"
+	case EvictReason_Capacity:
+		return "capacity"
+	case EvictReason_Deleted:
+		return "deleted"
+	default:
+		return "unknown"
+	}
+}
+
+// Option customize the Cache.
+type Option[K comparable, V any] func(*Cache[K, V])
+
+// WithCapacity set the maximum number of entries, the least recently used entry is evicted
+// when the cache is full, 0 means unlimited.
+func WithCapacity[K comparable, V any](capacity int) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.capacity = capacity
+	}
+}
+
+// WithSlidingExpiration reschedule the expiration of the entry on every read.
+func WithSlidingExpiration[K comparable, V any]() Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.sliding = true
+	}
+}
+
+// WithOnEvicted set the eviction callback, it is called without the cache lock.
+func WithOnEvicted[K comparable, V any](f func(K, V, EvictReason)) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.onEvicted = f
+	}
+}
+
+type entry[K comparable, V any] struct {
+	key      K
+	value    V
+	ttl      time.Duration
+	expireAt time.Time   // zero if never expires.
+	task     *timer.Task // nil if never expires.
+}
+
+// Cache a generic in-memory cache with per-entry time-to-live, it is safe for concurrent use.
+type Cache[K comparable, V any] struct {
+	timer     *timer.Timer
+	capacity  int
+	sliding   bool
+	onEvicted func(K, V, EvictReason)
+	mu        sync.Mutex          // protects following fields.
+	items     map[K]*list.Element // of *entry[K, V]
+	lru       *list.List          // front is the most recently used.
+}
+
+// New new cache, all expirations are scheduled on the timer t.
+func New[K comparable, V any](t *timer.Timer, opts ...Option[K, V]) *Cache[K, V] {
+	c := &Cache[K, V]{
+		timer: t,
+		items: make(map[K]*list.Element),
+		lru:   list.New(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Set the value of the key with ttl, ttl <= 0 means never expires.
+// It replaces the existing entry of the key.
+func (c *Cache[K, V]) Set(key K, value V, ttl time.Duration) error {
+	e := &entry[K, V]{
+		key:   key,
+		value: value,
+		ttl:   ttl,
+	}
+	if ttl > 0 {
+		e.expireAt = time.Now().Add(ttl)
+		e.task = timer.NewTaskFunc(ttl, func() { c.expire(e) }).WithNoSkip().WithNoSpreading()
+	}
+
+	c.mu.Lock()
+	if e.task != nil {
+		if err := c.timer.AddTask(e.task); err != nil {
+			c.mu.Unlock()
+			return err
+		}
+	}
+	var evicted []*entry[K, V]
+	var reasons []EvictReason
+	if elem, ok := c.items[key]; ok {
+		old := c.removeElement(elem)
+		evicted, reasons = append(evicted, old), append(reasons, EvictReason_Deleted)
+	}
+	c.items[key] = c.lru.PushFront(e)
+	for c.capacity > 0 && c.lru.Len() > c.capacity {
+		old := c.removeElement(c.lru.Back())
+		evicted, reasons = append(evicted, old), append(reasons, EvictReason_Capacity)
+	}
+	c.mu.Unlock()
+
+	for i, old := range evicted {
+		c.evicted(old, reasons[i])
+	}
+	return nil
+}
+
+// Get the value of the key, and mark it as recently used.
+// if sliding expiration is enabled, the expiration is rescheduled.
+func (c *Cache[K, V]) Get(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[key]
+	if !ok {
+		return value, false
+	}
+	e := elem.Value.(*entry[K, V])
+	if e.expired(time.Now()) { // expired, but the timer has not evicted it yet.
+		return value, false
+	}
+	c.lru.MoveToFront(elem)
+	if c.sliding && e.task != nil {
+		e.expireAt = time.Now().Add(e.ttl)
+		_ = c.timer.AddTask(e.task)
+	}
+	return e.value, true
+}
+
+// Peek the value of the key, without updating the recently used or expiration.
+func (c *Cache[K, V]) Peek(key K) (value V, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[key]
+	if !ok {
+		return value, false
+	}
+	e := elem.Value.(*entry[K, V])
+	if e.expired(time.Now()) {
+		return value, false
+	}
+	return e.value, true
+}
+
+// TTL return the remaining time to live of the key,
+// NoExpiration if the key never expires, false if the key not exist.
+func (c *Cache[K, V]) TTL(key K) (time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[key]
+	if !ok {
+		return 0, false
+	}
+	e := elem.Value.(*entry[K, V])
+	if e.task == nil {
+		return NoExpiration, true
+	}
+	now := time.Now()
+	if e.expired(now) {
+		return 0, false
+	}
+	return e.expireAt.Sub(now), true
+}
+
+// Delete the key, return true if the key existed.
+func (c *Cache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	elem, ok := c.items[key]
+	if !ok {
+		c.mu.Unlock()
+		return false
+	}
+	e := c.removeElement(elem)
+	c.mu.Unlock()
+	c.evicted(e, EvictReason_Deleted)
+	return true
+}
+
+// Len returns the number of entries, including those expired but not yet evicted.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Clear removes all the entries, the eviction callback is not called.
+func (c *Cache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for elem := c.lru.Front(); elem != nil; elem = elem.Next() {
+		if e := elem.Value.(*entry[K, V]); e.task != nil {
+			e.task.Cancel()
+		}
+	}
+	c.items = make(map[K]*list.Element)
+	c.lru.Init()
+}
+
+func (c *Cache[K, V]) expire(e *entry[K, V]) {
+	c.mu.Lock()
+	elem, ok := c.items[e.key]
+	// replaced or deleted, or rescheduled by sliding expiration.
+	if !ok || elem.Value.(*entry[K, V]) != e || e.task.Activated() {
+		c.mu.Unlock()
+		return
+	}
+	c.removeElement(elem)
+	c.mu.Unlock()
+	c.evicted(e, EvictReason_Expired)
+}
+
+// NOTE: should be call when `Cache.mu` lock.
+func (c *Cache[K, V]) removeElement(elem *list.Element) *entry[K, V] {
+	e := c.lru.Remove(elem).(*entry[K, V])
+	delete(c.items, e.key)
+	if e.task != nil {
+		e.task.Cancel()
+	}
+	return e
+}
+
+func (c *Cache[K, V]) evicted(e *entry[K, V], reason EvictReason) {
+	if c.onEvicted != nil {
+		c.onEvicted(e.key, e.value, reason)
+	}
+}
+
+func (e *entry[K, V]) expired(now time.Time) bool {
+	return e.task != nil && !now.Before(e.expireAt)
+}
@@ -0,0 +1,136 @@
+package ttlcache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thinkgos/timer"
+)
+
+type evictedEntry struct {
+	key    string
+	value  int
+	reason EvictReason
+}
+
+func newTestCache(t *testing.T, opts ...Option[string, int]) (*Cache[string, int], func() []evictedEntry) {
+	tm := timer.NewTimer()
+	tm.Start()
+	t.Cleanup(tm.Stop)
+
+	var mu sync.Mutex
+	var evicted []evictedEntry
+	opts = append(opts, WithOnEvicted(func(key string, value int, reason EvictReason) {
+		mu.Lock()
+		evicted = append(evicted, evictedEntry{key, value, reason})
+		mu.Unlock()
+	}))
+	return New(tm, opts...), func() []evictedEntry {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]evictedEntry(nil), evicted...)
+	}
+}
+
+func Test_Cache(t *testing.T) {
+	c, evicted := newTestCache(t)
+
+	require.NoError(t, c.Set("a", 1, 30*time.Millisecond))
+	require.NoError(t, c.Set("b", 2, NoExpiration))
+	require.Equal(t, 2, c.Len())
+
+	v, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+	ttl, ok := c.TTL("a")
+	require.True(t, ok)
+	require.LessOrEqual(t, ttl, 30*time.Millisecond)
+	ttl, ok = c.TTL("b")
+	require.True(t, ok)
+	require.Equal(t, NoExpiration, ttl)
+	_, ok = c.TTL("c")
+	require.False(t, ok)
+
+	require.Eventually(t, func() bool { return c.Len() == 1 }, time.Second, time.Millisecond)
+	_, ok = c.Get("a")
+	require.False(t, ok)
+	v, ok = c.Peek("b")
+	require.True(t, ok)
+	require.Equal(t, 2, v)
+	require.Equal(t, []evictedEntry{{"a", 1, EvictReason_Expired}}, evicted())
+
+	// replace
+	require.NoError(t, c.Set("b", 3, 20*time.Millisecond))
+	require.NoError(t, c.Set("b", 4, NoExpiration))
+	time.Sleep(50 * time.Millisecond)
+	v, ok = c.Get("b")
+	require.True(t, ok)
+	require.Equal(t, 4, v)
+
+	// delete
+	require.True(t, c.Delete("b"))
+	require.False(t, c.Delete("b"))
+	require.Zero(t, c.Len())
+	require.Equal(t, []evictedEntry{
+		{"a", 1, EvictReason_Expired},
+		{"b", 2, EvictReason_Deleted},
+		{"b", 3, EvictReason_Deleted},
+		{"b", 4, EvictReason_Deleted},
+	}, evicted())
+
+	// clear
+	require.NoError(t, c.Set("c", 5, 20*time.Millisecond))
+	c.Clear()
+	require.Zero(t, c.Len())
+	time.Sleep(50 * time.Millisecond)
+	require.Len(t, evicted(), 4)
+}
+
+func Test_Cache_SlidingExpiration(t *testing.T) {
+	c, evicted := newTestCache(t, WithSlidingExpiration[string, int]())
+
+	require.NoError(t, c.Set("a", 1, 40*time.Millisecond))
+	require.NoError(t, c.Set("b", 2, 40*time.Millisecond))
+	for i := 0; i < 10; i++ { // 100ms, keep "a" alive.
+		_, ok := c.Get("a")
+		require.True(t, ok)
+		time.Sleep(10 * time.Millisecond)
+	}
+	_, ok := c.Peek("b")
+	require.False(t, ok)
+	require.Equal(t, []evictedEntry{{"b", 2, EvictReason_Expired}}, evicted())
+
+	// peek does not touch.
+	require.Eventually(t, func() bool { return c.Len() == 0 }, time.Second, time.Millisecond)
+}
+
+func Test_Cache_Capacity(t *testing.T) {
+	c, evicted := newTestCache(t, WithCapacity[string, int](2))
+
+	require.NoError(t, c.Set("a", 1, time.Hour))
+	require.NoError(t, c.Set("b", 2, time.Hour))
+	_, ok := c.Get("a") // "b" is the least recently used.
+	require.True(t, ok)
+	require.NoError(t, c.Set("c", 3, time.Hour))
+	require.Equal(t, 2, c.Len())
+	_, ok = c.Get("b")
+	require.False(t, ok)
+	require.Equal(t, []evictedEntry{{"b", 2, EvictReason_Capacity}}, evicted())
+}
+
+func Test_Cache_TimerClosed(t *testing.T) {
+	c := New[string, int](timer.NewTimer())
+	require.ErrorIs(t, c.Set("a", 1, time.Second), timer.ErrClosed)
+	require.Zero(t, c.Len())
+	require.NoError(t, c.Set("a", 1, NoExpiration))
+	require.Equal(t, 1, c.Len())
+}
+
+func Test_EvictReason_String(t *testing.T) {
+	require.Equal(t, "expired", EvictReason_Expired.String())
+	require.Equal(t, "capacity", EvictReason_Capacity.String())
+	require.Equal(t, "deleted", EvictReason_Deleted.String())
+	require.Equal(t, "unknown", EvictReason(100).String())
+}